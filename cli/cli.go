@@ -19,12 +19,16 @@ package cli
 import (
 	gocontext "context"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
 
 	api "github.com/containerd/cri/pkg/api/v1"
 	"github.com/containerd/cri/pkg/client"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Command is the cli command for cri plugin.
@@ -33,15 +37,28 @@ var Command = cli.Command{
 	Usage: "interact with cri plugin",
 	Subcommands: cli.Commands{
 		loadCommand,
+		exportCommand,
+		checkpointCommand,
+		restoreCommand,
 	},
 }
 
+// loadChunkSize is the size of archive chunks sent by streaming load.
+const loadChunkSize = 1 << 20
+
 var loadCommand = cli.Command{
-	Name:        "load",
-	Usage:       "load one or more images from tar archives.",
-	ArgsUsage:   "[flags] TAR [TAR, ...]",
-	Description: "load one or more images from tar archives.",
-	Flags:       []cli.Flag{},
+	Name:      "load",
+	Usage:     "load one or more images from tar archives.",
+	ArgsUsage: "[flags] TAR [TAR, ...]",
+	Description: `load one or more images from tar archives, which may be compressed with gzip or zstd.
+The archive is read from stdin if TAR is "-". The archive is streamed to cri plugin
+if it is read from stdin or it is not found on the filesystem of cri plugin.`,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "stream",
+			Usage: "always stream the archive to cri plugin",
+		},
+	},
 	Action: func(context *cli.Context) error {
 		var (
 			ctx     = gocontext.Background()
@@ -60,15 +77,10 @@ var loadCommand = cli.Command{
 		}
 		defer cancel()
 		for _, path := range context.Args() {
-			absPath, err := filepath.Abs(path)
-			if err != nil {
-				return errors.Wrap(err, "failed to get absolute path")
-			}
-			res, err := cl.LoadImage(ctx, &api.LoadImageRequest{FilePath: absPath})
+			images, err := loadImage(ctx, cl, path, context.Bool("stream"))
 			if err != nil {
 				return errors.Wrap(err, "failed to load image")
 			}
-			images := res.GetImages()
 			for _, image := range images {
 				fmt.Println("Loaded image:", image)
 			}
@@ -76,3 +88,230 @@ var loadCommand = cli.Command{
 		return nil
 	},
 }
+
+// loadImage loads images from the archive. The archive is loaded by path if
+// it is on the filesystem of cri plugin, otherwise it is streamed.
+func loadImage(ctx gocontext.Context, cl api.CRIPluginServiceClient, path string, stream bool) ([]string, error) {
+	if path == "-" {
+		return loadImageStream(ctx, cl, os.Stdin)
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get absolute path")
+	}
+	if !stream {
+		res, err := cl.LoadImage(ctx, &api.LoadImageRequest{FilePath: absPath})
+		if err == nil {
+			return res.GetImages(), nil
+		}
+		if s, ok := status.FromError(err); !ok || s.Code() != codes.NotFound {
+			return nil, err
+		}
+	}
+	f, err := os.Open(absPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open file")
+	}
+	defer f.Close()
+	return loadImageStream(ctx, cl, f)
+}
+
+// loadImageStream streams the archive read from the reader to cri plugin
+// in chunks.
+func loadImageStream(ctx gocontext.Context, cl api.CRIPluginServiceClient, r io.Reader) ([]string, error) {
+	stream, err := cl.LoadImageStream(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create stream")
+	}
+	buf := make([]byte, loadChunkSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&api.LoadImageStreamRequest{Data: buf[:n]}); err != nil {
+				if err == io.EOF {
+					// The server closed the stream, the error is returned
+					// by CloseAndRecv.
+					break
+				}
+				return nil, errors.Wrap(err, "failed to send archive")
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read archive")
+		}
+	}
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, err
+	}
+	return res.GetImages(), nil
+}
+
+var exportCommand = cli.Command{
+	Name:      "export",
+	Usage:     "export one or more images into a tar archive.",
+	ArgsUsage: "[flags] IMAGE [IMAGE, ...]",
+	Description: `export one or more images into a tar archive. Each image can be an image id,
+tag or digest. The archive is written to stdout if --output is not specified.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "output, o",
+			Usage: "write the archive to the file",
+		},
+		cli.StringFlag{
+			Name:  "format",
+			Usage: "format of the archive, docker or oci",
+			Value: "docker",
+		},
+	},
+	Action: func(context *cli.Context) error {
+		var (
+			ctx     = gocontext.Background()
+			address = context.GlobalString("address")
+			timeout = context.GlobalDuration("timeout")
+			cancel  gocontext.CancelFunc
+		)
+		if context.NArg() == 0 {
+			return errors.New("no image specified")
+		}
+		var format api.ExportFormat
+		switch context.String("format") {
+		case "docker":
+			format = api.ExportFormat_DOCKER
+		case "oci":
+			format = api.ExportFormat_OCI
+		default:
+			return errors.Errorf("unsupported format %q", context.String("format"))
+		}
+		cl, err := client.NewCRIPluginClient(address, timeout)
+		if err != nil {
+			return errors.Wrap(err, "failed to create grpc client")
+		}
+		if timeout > 0 {
+			ctx, cancel = gocontext.WithTimeout(gocontext.Background(), timeout)
+		} else {
+			ctx, cancel = gocontext.WithCancel(ctx)
+		}
+		defer cancel()
+		w := os.Stdout
+		if output := context.String("output"); output != "" {
+			f, err := os.Create(output)
+			if err != nil {
+				return errors.Wrap(err, "failed to create output file")
+			}
+			defer f.Close()
+			w = f
+		}
+		stream, err := cl.ExportImage(ctx, &api.ExportImageRequest{
+			Images: context.Args(),
+			Format: format,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to export image")
+		}
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return errors.Wrap(err, "failed to export image")
+			}
+			if _, err := w.Write(res.GetData()); err != nil {
+				return errors.Wrap(err, "failed to write archive")
+			}
+		}
+	},
+}
+
+var checkpointCommand = cli.Command{
+	Name:      "checkpoint",
+	Usage:     "checkpoint a running container into an archive.",
+	ArgsUsage: "[flags] CONTAINER PATH",
+	Description: `checkpoint a running container with CRIU into an archive at PATH on the
+filesystem of cri plugin. The container keeps running unless --exit is specified.`,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "exit",
+			Usage: "stop the container after it is checkpointed",
+		},
+	},
+	Action: func(context *cli.Context) error {
+		var (
+			ctx     = gocontext.Background()
+			address = context.GlobalString("address")
+			timeout = context.GlobalDuration("timeout")
+			cancel  gocontext.CancelFunc
+		)
+		if context.NArg() != 2 {
+			return errors.New("container and path must be specified")
+		}
+		path, err := filepath.Abs(context.Args().Get(1))
+		if err != nil {
+			return errors.Wrap(err, "failed to get absolute path")
+		}
+		cl, err := client.NewCRIPluginClient(address, timeout)
+		if err != nil {
+			return errors.Wrap(err, "failed to create grpc client")
+		}
+		if timeout > 0 {
+			ctx, cancel = gocontext.WithTimeout(gocontext.Background(), timeout)
+		} else {
+			ctx, cancel = gocontext.WithCancel(ctx)
+		}
+		defer cancel()
+		if _, err := cl.CheckpointContainer(ctx, &api.CheckpointContainerRequest{
+			ContainerId: context.Args().First(),
+			Path:        path,
+			Exit:        context.Bool("exit"),
+		}); err != nil {
+			return errors.Wrap(err, "failed to checkpoint container")
+		}
+		return nil
+	},
+}
+
+var restoreCommand = cli.Command{
+	Name:      "restore",
+	Usage:     "restore a container from a checkpoint archive.",
+	ArgsUsage: "SANDBOX PATH",
+	Description: `restore a container from the checkpoint archive at PATH on the filesystem
+of cri plugin into a ready pod sandbox, and print the id of the restored container.`,
+	Action: func(context *cli.Context) error {
+		var (
+			ctx     = gocontext.Background()
+			address = context.GlobalString("address")
+			timeout = context.GlobalDuration("timeout")
+			cancel  gocontext.CancelFunc
+		)
+		if context.NArg() != 2 {
+			return errors.New("sandbox and path must be specified")
+		}
+		path, err := filepath.Abs(context.Args().Get(1))
+		if err != nil {
+			return errors.Wrap(err, "failed to get absolute path")
+		}
+		cl, err := client.NewCRIPluginClient(address, timeout)
+		if err != nil {
+			return errors.Wrap(err, "failed to create grpc client")
+		}
+		if timeout > 0 {
+			ctx, cancel = gocontext.WithTimeout(gocontext.Background(), timeout)
+		} else {
+			ctx, cancel = gocontext.WithCancel(ctx)
+		}
+		defer cancel()
+		res, err := cl.RestoreContainer(ctx, &api.RestoreContainerRequest{
+			PodSandboxId: context.Args().First(),
+			Path:         path,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to restore container")
+		}
+		fmt.Println(res.GetContainerId())
+		return nil
+	},
+}
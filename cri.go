@@ -58,18 +58,26 @@ func init() {
 }
 
 func initCRIService(ic *plugin.InitContext) (interface{}, error) {
-	ic.Meta.Platforms = []imagespec.Platform{platforms.DefaultSpec()}
 	ic.Meta.Exports = map[string]string{"CRIVersion": constants.CRIVersion}
 	ctx := ic.Context
 	pluginConfig := ic.Config.(*criconfig.PluginConfig)
+	if err := criconfig.ValidatePluginConfig(pluginConfig); err != nil {
+		return nil, errors.Wrap(err, "invalid plugin config")
+	}
+	ps, err := parsePlatforms(pluginConfig.Platforms)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse platforms")
+	}
+	ic.Meta.Platforms = ps
 	c := criconfig.Config{
 		PluginConfig:       *pluginConfig,
 		ContainerdRootDir:  filepath.Dir(ic.Root),
 		ContainerdEndpoint: ic.Address,
 		RootDir:            ic.Root,
 		StateDir:           ic.State,
+		ParsedPlatforms:    ic.Meta.Platforms,
 	}
-	log.G(ctx).Infof("Start cri plugin with config %+v", c)
+	log.G(ctx).Infof("Start cri plugin with config %+v", c.Redacted())
 
 	if err := setGLogLevel(); err != nil {
 		return nil, errors.Wrap(err, "failed to set glog level")
@@ -177,3 +185,20 @@ func setGLogLevel() error {
 	}
 	return nil
 }
+
+// parsePlatforms parses platforms in the config, the platform of the node
+// is returned if there is no platform configured.
+func parsePlatforms(platformList []string) ([]imagespec.Platform, error) {
+	if len(platformList) == 0 {
+		return []imagespec.Platform{platforms.DefaultSpec()}, nil
+	}
+	var ps []imagespec.Platform
+	for _, p := range platformList {
+		parsed, err := platforms.Parse(p)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse platform %q", p)
+		}
+		ps = append(ps, platforms.Normalize(parsed))
+	}
+	return ps, nil
+}
@@ -37,7 +37,8 @@ trap cleanup EXIT
 protoc \
   --proto_path="${API_ROOT}" \
   --proto_path="${ROOT}/vendor" \
-  --gogo_out=plugins=grpc:${API_ROOT} ${API_ROOT}/api.proto
+  --proto_path="${ROOT}/vendor/github.com/gogo/protobuf" \
+  --gogo_out=plugins=grpc,Mgogoproto/gogo.proto=github.com/gogo/protobuf/gogoproto,Mgithub.com/containerd/cgroups/metrics.proto=github.com/containerd/cgroups:${API_ROOT} ${API_ROOT}/api.proto
 
 # Update boilerplate for the generated file.
 echo "$(cat hack/boilerplate/boilerplate.go.txt ${API_ROOT}/api.pb.go)" > ${API_ROOT}/api.pb.go
@@ -0,0 +1,119 @@
+/*
+Copyright 2018 The Containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
+
+	api "github.com/containerd/cri/pkg/api/v1"
+)
+
+// Test to checkpoint a container and restore it into the sandbox.
+func TestContainerCheckpointRestore(t *testing.T) {
+	if _, err := exec.LookPath("criu"); err != nil {
+		t.Skip("criu is not installed")
+	}
+	const (
+		testImage   = "busybox"
+		execTimeout = time.Minute
+	)
+
+	t.Logf("Create a sandbox")
+	sbConfig := PodSandboxConfig("sandbox", "checkpoint-restore")
+	sb, err := runtimeService.RunPodSandbox(sbConfig)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, runtimeService.StopPodSandbox(sb))
+		assert.NoError(t, runtimeService.RemovePodSandbox(sb))
+	}()
+
+	t.Logf("Pull test image")
+	_, err = imageService.PullImage(&runtime.ImageSpec{Image: testImage}, nil)
+	require.NoError(t, err)
+
+	t.Logf("Create and start a container")
+	cnConfig := ContainerConfig(
+		"container",
+		testImage,
+		WithCommand("sh", "-c", "echo started > /state; tail -f /dev/null"),
+	)
+	cn, err := runtimeService.CreateContainer(sb, cnConfig, sbConfig)
+	require.NoError(t, err)
+	require.NoError(t, runtimeService.StartContainer(cn))
+
+	t.Logf("Update the writable layer of the container")
+	_, _, err = runtimeService.ExecSync(cn, []string{"sh", "-c", "echo updated > /state"}, execTimeout)
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "checkpoint")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "checkpoint.tar")
+
+	t.Logf("Checkpoint the container with exit")
+	_, err = criPluginClient.CheckpointContainer(context.Background(), &api.CheckpointContainerRequest{
+		ContainerId: cn,
+		Path:        path,
+		Exit:        true,
+	})
+	require.NoError(t, err)
+	_, err = os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, Eventually(func() (bool, error) {
+		s, err := runtimeService.ContainerStatus(cn)
+		if err != nil {
+			return false, err
+		}
+		return s.GetState() == runtime.ContainerState_CONTAINER_EXITED, nil
+	}, time.Second, 30*time.Second))
+
+	t.Logf("Remove the checkpointed container")
+	require.NoError(t, runtimeService.RemoveContainer(cn))
+
+	t.Logf("Restore the container into the sandbox")
+	res, err := criPluginClient.RestoreContainer(context.Background(), &api.RestoreContainerRequest{
+		PodSandboxId: sb,
+		Path:         path,
+	})
+	require.NoError(t, err)
+	restored := res.GetContainerId()
+	defer func() {
+		assert.NoError(t, runtimeService.StopContainer(restored, 10))
+		assert.NoError(t, runtimeService.RemoveContainer(restored))
+	}()
+
+	t.Logf("Check the restored container is running")
+	s, err := runtimeService.ContainerStatus(restored)
+	require.NoError(t, err)
+	assert.Equal(t, runtime.ContainerState_CONTAINER_RUNNING, s.GetState())
+	assert.Equal(t, cnConfig.GetMetadata().GetName(), s.GetMetadata().GetName())
+
+	t.Logf("Check the writable layer of the container is restored")
+	stdout, _, err := runtimeService.ExecSync(restored, []string{"cat", "/state"}, execTimeout)
+	require.NoError(t, err)
+	assert.Equal(t, "updated\n", string(stdout))
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2018 The Containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
+
+	api "github.com/containerd/cri/pkg/api/v1"
+)
+
+// Test to pause and resume containers and pod sandboxes.
+func TestContainerPauseResume(t *testing.T) {
+	const (
+		testImage   = "busybox"
+		execTimeout = time.Minute
+	)
+	ctx := context.Background()
+
+	t.Logf("Create a sandbox")
+	sbConfig := PodSandboxConfig("sandbox", "pause-resume")
+	sb, err := runtimeService.RunPodSandbox(sbConfig)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, runtimeService.StopPodSandbox(sb))
+		assert.NoError(t, runtimeService.RemovePodSandbox(sb))
+	}()
+
+	t.Logf("Pull test image")
+	_, err = imageService.PullImage(&runtime.ImageSpec{Image: testImage}, nil)
+	require.NoError(t, err)
+
+	t.Logf("Create and start a container")
+	cnConfig := ContainerConfig(
+		"container",
+		testImage,
+		WithCommand("tail", "-f", "/dev/null"),
+	)
+	cn, err := runtimeService.CreateContainer(sb, cnConfig, sbConfig)
+	require.NoError(t, err)
+	require.NoError(t, runtimeService.StartContainer(cn))
+
+	taskStatus := func() containerd.ProcessStatus {
+		cntr, err := containerdClient.LoadContainer(ctx, cn)
+		require.NoError(t, err)
+		task, err := cntr.Task(ctx, nil)
+		require.NoError(t, err)
+		s, err := task.Status(ctx)
+		require.NoError(t, err)
+		return s.Status
+	}
+
+	t.Logf("Pause the container")
+	_, err = criPluginClient.PauseContainer(ctx, &api.PauseContainerRequest{ContainerId: cn})
+	require.NoError(t, err)
+	assert.Equal(t, containerd.Paused, taskStatus())
+
+	t.Logf("Paused container should still be running")
+	s, err := runtimeService.ContainerStatus(cn)
+	require.NoError(t, err)
+	assert.Equal(t, runtime.ContainerState_CONTAINER_RUNNING, s.GetState())
+
+	t.Logf("Exec should fail in paused container")
+	_, _, err = runtimeService.ExecSync(cn, []string{"true"}, execTimeout)
+	assert.Error(t, err)
+
+	t.Logf("Resume the container")
+	_, err = criPluginClient.ResumeContainer(ctx, &api.ResumeContainerRequest{ContainerId: cn})
+	require.NoError(t, err)
+	assert.Equal(t, containerd.Running, taskStatus())
+	_, _, err = runtimeService.ExecSync(cn, []string{"true"}, execTimeout)
+	assert.NoError(t, err)
+
+	t.Logf("Pause the pod sandbox")
+	_, err = criPluginClient.PausePodSandbox(ctx, &api.PausePodSandboxRequest{PodSandboxId: sb})
+	require.NoError(t, err)
+	assert.Equal(t, containerd.Paused, taskStatus())
+
+	t.Logf("Resume the pod sandbox")
+	_, err = criPluginClient.ResumePodSandbox(ctx, &api.ResumePodSandboxRequest{PodSandboxId: sb})
+	require.NoError(t, err)
+	assert.Equal(t, containerd.Running, taskStatus())
+
+	t.Logf("Stop should succeed for paused container")
+	_, err = criPluginClient.PauseContainer(ctx, &api.PauseContainerRequest{ContainerId: cn})
+	require.NoError(t, err)
+	require.NoError(t, runtimeService.StopContainer(cn, 10))
+	s, err = runtimeService.ContainerStatus(cn)
+	require.NoError(t, err)
+	assert.Equal(t, runtime.ContainerState_CONTAINER_EXITED, s.GetState())
+	require.NoError(t, runtimeService.RemoveContainer(cn))
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
+
+	api "github.com/containerd/cri/pkg/api/v1"
+)
+
+// Test to export images and load them back.
+func TestImageExportRoundTrip(t *testing.T) {
+	const (
+		testImage   = "busybox:latest"
+		loadedImage = "docker.io/library/" + testImage
+	)
+	t.Logf("pull the test image")
+	id, err := imageService.PullImage(&runtime.ImageSpec{Image: testImage}, nil)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, imageService.RemoveImage(&runtime.ImageSpec{Image: id}))
+	}()
+
+	for _, format := range []api.ExportFormat{api.ExportFormat_DOCKER, api.ExportFormat_OCI} {
+		t.Logf("export image in %v format", format)
+		stream, err := criPluginClient.ExportImage(context.Background(), &api.ExportImageRequest{
+			Images: []string{testImage},
+			Format: format,
+		})
+		require.NoError(t, err)
+		var archive bytes.Buffer
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			archive.Write(res.GetData())
+		}
+
+		t.Logf("remove the image")
+		require.NoError(t, imageService.RemoveImage(&runtime.ImageSpec{Image: id}))
+		img, err := imageService.ImageStatus(&runtime.ImageSpec{Image: testImage})
+		require.NoError(t, err)
+		require.Nil(t, img)
+
+		t.Logf("load the exported archive")
+		load, err := criPluginClient.LoadImageStream(context.Background())
+		require.NoError(t, err)
+		require.NoError(t, load.Send(&api.LoadImageStreamRequest{Data: archive.Bytes()}))
+		res, err := load.CloseAndRecv()
+		require.NoError(t, err)
+		require.Equal(t, []string{loadedImage}, res.GetImages())
+
+		t.Logf("make sure the image is loaded with the same id")
+		img, err = imageService.ImageStatus(&runtime.ImageSpec{Image: testImage})
+		require.NoError(t, err)
+		require.NotNil(t, img)
+		assert.Equal(t, id, img.Id)
+		assert.Equal(t, []string{loadedImage}, img.RepoTags)
+	}
+}
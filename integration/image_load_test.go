@@ -17,6 +17,8 @@ limitations under the License.
 package integration
 
 import (
+	"bytes"
+	"compress/gzip"
 	"golang.org/x/net/context"
 	"io/ioutil"
 	"os"
@@ -91,3 +93,53 @@ func TestImageLoad(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, runtime.ContainerState_CONTAINER_RUNNING, status.State)
 }
+
+// Test to load a gzip compressed image tarball by streaming.
+func TestImageLoadStream(t *testing.T) {
+	testImage := "busybox:latest"
+	loadedImage := "docker.io/library/" + testImage
+	_, err := exec.LookPath("docker")
+	if err != nil {
+		t.Skipf("Docker is not available: %v", err)
+	}
+	t.Logf("docker save image into compressed tarball")
+	output, err := exec.Command("docker", "pull", testImage).CombinedOutput()
+	require.NoError(t, err, "output: %q", output)
+	tar, err := exec.Command("docker", "save", testImage).Output()
+	require.NoError(t, err)
+	var compressed bytes.Buffer
+	gw := gzip.NewWriter(&compressed)
+	_, err = gw.Write(tar)
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+
+	t.Logf("make sure no such image in cri")
+	img, err := imageService.ImageStatus(&runtime.ImageSpec{Image: testImage})
+	require.NoError(t, err)
+	if img != nil {
+		require.NoError(t, imageService.RemoveImage(&runtime.ImageSpec{Image: testImage}))
+	}
+
+	t.Logf("stream image into cri in chunks")
+	stream, err := criPluginClient.LoadImageStream(context.Background())
+	require.NoError(t, err)
+	const chunkSize = 64 * 1024
+	data := compressed.Bytes()
+	for len(data) > 0 {
+		n := chunkSize
+		if n > len(data) {
+			n = len(data)
+		}
+		require.NoError(t, stream.Send(&api.LoadImageStreamRequest{Data: data[:n]}))
+		data = data[n:]
+	}
+	res, err := stream.CloseAndRecv()
+	require.NoError(t, err)
+	require.Equal(t, []string{loadedImage}, res.GetImages())
+
+	t.Logf("make sure image is loaded")
+	img, err = imageService.ImageStatus(&runtime.ImageSpec{Image: testImage})
+	require.NoError(t, err)
+	require.NotNil(t, img)
+	require.Equal(t, []string{loadedImage}, img.RepoTags)
+}
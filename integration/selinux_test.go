@@ -0,0 +1,88 @@
+/*
+Copyright 2018 The Containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/opencontainers/selinux/go-selinux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
+)
+
+func TestSELinuxSandboxFilesAndImageVolume(t *testing.T) {
+	if !selinux.GetEnabled() {
+		t.Skip("selinux is not enabled")
+	}
+	const (
+		testImage   = "gcr.io/k8s-cri-containerd/volume-copy-up:1.0"
+		execTimeout = time.Minute
+	)
+	selinuxOptions := &runtime.SELinuxOption{Level: "s0:c100,c200"}
+
+	t.Logf("Create a sandbox with selinux options")
+	sbConfig := PodSandboxConfig("sandbox", "selinux", func(p *runtime.PodSandboxConfig) {
+		p.Linux.SecurityContext = &runtime.LinuxSandboxSecurityContext{
+			SelinuxOptions: selinuxOptions,
+		}
+	})
+	sb, err := runtimeService.RunPodSandbox(sbConfig)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, runtimeService.StopPodSandbox(sb))
+		assert.NoError(t, runtimeService.RemovePodSandbox(sb))
+	}()
+
+	t.Logf("Pull test image")
+	_, err = imageService.PullImage(&runtime.ImageSpec{Image: testImage}, nil)
+	require.NoError(t, err)
+
+	t.Logf("Create a container with the same selinux options")
+	cnConfig := ContainerConfig(
+		"container",
+		testImage,
+		WithCommand("tail", "-f", "/dev/null"),
+		func(c *runtime.ContainerConfig) {
+			c.Linux = &runtime.LinuxContainerConfig{
+				SecurityContext: &runtime.LinuxContainerSecurityContext{
+					SelinuxOptions: selinuxOptions,
+				},
+			}
+		},
+	)
+	cn, err := runtimeService.CreateContainer(sb, cnConfig, sbConfig)
+	require.NoError(t, err)
+	require.NoError(t, runtimeService.StartContainer(cn))
+	defer func() {
+		assert.NoError(t, runtimeService.StopContainer(cn, 10))
+		assert.NoError(t, runtimeService.RemoveContainer(cn))
+	}()
+
+	for desc, cmd := range map[string]string{
+		"/etc/hosts should be readable":       "cat /etc/hosts",
+		"/etc/resolv.conf should be readable": "cat /etc/resolv.conf",
+		"/dev/shm should be writable":         "touch /dev/shm/test-file",
+		"image volume should be writable":     "echo new_content > /test_dir/test_file",
+	} {
+		t.Logf("TestCase %q", desc)
+		_, stderr, err := runtimeService.ExecSync(cn, []string{"sh", "-c", cmd}, execTimeout)
+		assert.NoError(t, err)
+		assert.Empty(t, stderr)
+	}
+}
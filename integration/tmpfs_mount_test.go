@@ -0,0 +1,93 @@
+/*
+Copyright 2018 The Containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
+
+	"github.com/containerd/cri/pkg/annotations"
+)
+
+func TestTmpfsRunMount(t *testing.T) {
+	const (
+		testImage   = "busybox"
+		execTimeout = time.Minute
+	)
+
+	t.Logf("Create a sandbox")
+	sbConfig := PodSandboxConfig("sandbox", "tmpfs-run-mount")
+	sb, err := runtimeService.RunPodSandbox(sbConfig)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, runtimeService.StopPodSandbox(sb))
+		assert.NoError(t, runtimeService.RemovePodSandbox(sb))
+	}()
+
+	t.Logf("Pull test image")
+	_, err = imageService.PullImage(&runtime.ImageSpec{Image: testImage}, nil)
+	require.NoError(t, err)
+
+	for desc, test := range map[string]struct {
+		annotations map[string]string
+		expectTmpfs bool
+	}{
+		"/run should be mounted as tmpfs": {
+			expectTmpfs: true,
+		},
+		"/run should not be mounted as tmpfs if disabled": {
+			annotations: map[string]string{annotations.DisableTmpfsMounts: "true"},
+		},
+	} {
+		t.Logf("TestCase %q", desc)
+		cnConfig := ContainerConfig(
+			"container",
+			testImage,
+			WithCommand("tail", "-f", "/dev/null"),
+			func(c *runtime.ContainerConfig) {
+				c.Annotations = test.annotations
+				c.Linux = &runtime.LinuxContainerConfig{
+					SecurityContext: &runtime.LinuxContainerSecurityContext{
+						ReadonlyRootfs: true,
+					},
+				}
+			},
+		)
+		cn, err := runtimeService.CreateContainer(sb, cnConfig, sbConfig)
+		require.NoError(t, err)
+		require.NoError(t, runtimeService.StartContainer(cn))
+
+		t.Logf("Check whether /run is writable in readonly rootfs")
+		_, _, err = runtimeService.ExecSync(cn, []string{
+			"sh",
+			"-c",
+			"grep -q '^tmpfs /run tmpfs' /proc/mounts && touch /run/test-file",
+		}, execTimeout)
+		if test.expectTmpfs {
+			assert.NoError(t, err)
+		} else {
+			assert.Error(t, err)
+		}
+
+		require.NoError(t, runtimeService.StopContainer(cn, 10))
+		require.NoError(t, runtimeService.RemoveContainer(cn))
+	}
+}
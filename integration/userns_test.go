@@ -0,0 +1,86 @@
+/*
+Copyright 2018 The Containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
+
+	"github.com/containerd/cri/pkg/annotations"
+)
+
+func TestSandboxUserNamespace(t *testing.T) {
+	const (
+		testImage   = "busybox"
+		execTimeout = time.Minute
+	)
+
+	t.Logf("Create a sandbox in user namespace")
+	sbConfig := PodSandboxConfig("sandbox", "user-namespace")
+	sbConfig.Annotations = map[string]string{annotations.UserNamespace: "true"}
+	sb, err := runtimeService.RunPodSandbox(sbConfig)
+	if err != nil && strings.Contains(err.Error(), "userns remap range is not configured") {
+		t.Skip("userns remap range is not configured")
+	}
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, runtimeService.StopPodSandbox(sb))
+		assert.NoError(t, runtimeService.RemovePodSandbox(sb))
+	}()
+
+	t.Logf("Pull test image")
+	_, err = imageService.PullImage(&runtime.ImageSpec{Image: testImage}, nil)
+	require.NoError(t, err)
+
+	t.Logf("Create a container in the sandbox")
+	cnConfig := ContainerConfig(
+		"container",
+		testImage,
+		WithCommand("tail", "-f", "/dev/null"),
+	)
+	cn, err := runtimeService.CreateContainer(sb, cnConfig, sbConfig)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, runtimeService.RemoveContainer(cn))
+	}()
+	require.NoError(t, runtimeService.StartContainer(cn))
+	defer func() {
+		assert.NoError(t, runtimeService.StopContainer(cn, 10))
+	}()
+
+	t.Logf("Check root in the container is mapped to a non-root host id")
+	stdout, _, err := runtimeService.ExecSync(cn, []string{"cat", "/proc/self/uid_map"}, execTimeout)
+	require.NoError(t, err)
+	fields := strings.Fields(string(stdout))
+	require.Len(t, fields, 3)
+	assert.Equal(t, "0", fields[0])
+	assert.NotEqual(t, "0", fields[1])
+
+	t.Logf("Check root in the container owns the rootfs")
+	_, _, err = runtimeService.ExecSync(cn, []string{"touch", "/etc/test-file"}, execTimeout)
+	assert.NoError(t, err)
+
+	t.Logf("Check the container has network in the sandbox")
+	status, err := runtimeService.PodSandboxStatus(sb)
+	require.NoError(t, err)
+	assert.NotEmpty(t, status.GetNetwork().GetIp())
+}
@@ -35,4 +35,23 @@ const (
 	// UntrustedWorkload is the sandbox annotation for untrusted workload. Untrusted
 	// workload can only run on dedicated runtime for untrusted workload.
 	UntrustedWorkload = "io.kubernetes.cri.untrusted-workload"
+
+	// RuntimeHandler is the sandbox annotation for the runtime handler to run
+	// the sandbox with, which is a key of the configured runtimes. The default
+	// runtime handler is used if it is not set.
+	RuntimeHandler = "io.kubernetes.cri.runtime-handler"
+
+	// WritableLayerLimit is the container annotation for the maximum size of
+	// the container writable layer, e.g. "10Gi". It overrides the default
+	// limit of the runtime.
+	WritableLayerLimit = "io.kubernetes.cri.writable-layer-limit"
+
+	// DisableTmpfsMounts is the container annotation to disable the tmpfs
+	// mounts of the runtime for the container, e.g. "true".
+	DisableTmpfsMounts = "io.kubernetes.cri.disable-tmpfs-mounts"
+
+	// UserNamespace is the sandbox annotation to run the sandbox and its
+	// containers in a user namespace, e.g. "true". It overrides the default
+	// of the runtime.
+	UserNamespace = "io.kubernetes.cri.user-namespace"
 )
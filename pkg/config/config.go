@@ -16,10 +16,32 @@ limitations under the License.
 
 package config
 
-import "github.com/containerd/containerd"
+import (
+	"path/filepath"
 
-// Runtime struct to contain the type(ID), engine, and root variables for a default runtime
-// and a runtime for untrusted worload.
+	"github.com/containerd/containerd"
+	imagespec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+const (
+	// RuntimeUntrusted is the runtime handler name of the runtime for
+	// untrusted workload.
+	RuntimeUntrusted = "untrusted"
+	// RuntimeDefault is the default runtime handler name.
+	RuntimeDefault = "runc"
+)
+
+const (
+	// PrivilegedWorkloadAllow allows sandboxes requiring host privilege on
+	// the runtime.
+	PrivilegedWorkloadAllow = "allow"
+	// PrivilegedWorkloadDeny denies sandboxes requiring host privilege on
+	// the runtime.
+	PrivilegedWorkloadDeny = "deny"
+)
+
+// Runtime struct to contain the type(ID), engine, and root variables for a runtime handler.
 type Runtime struct {
 	// Type is the runtime type to use in containerd e.g. io.containerd.runtime.v1.linux
 	Type string `toml:"runtime_type" json:"runtimeType"`
@@ -27,15 +49,52 @@ type Runtime struct {
 	Engine string `toml:"runtime_engine" json:"runtimeEngine"`
 	// Root is the directory used by containerd for runtime state.
 	Root string `toml:"runtime_root" json:"runtimeRoot"`
+	// WritableLayerLimit is the default maximum size in bytes of the writable
+	// layer of containers running on the runtime. It can be overridden by
+	// container annotation. 0 means no limit.
+	WritableLayerLimit int64 `toml:"writable_layer_limit" json:"writableLayerLimit"`
+	// PrivilegedWorkload is the policy of sandboxes requiring host privilege,
+	// i.e. privileged sandboxes and sandboxes using host namespaces, on the
+	// runtime. It is either "allow" or "deny", and "allow" if not set.
+	PrivilegedWorkload string `toml:"privileged_workload" json:"privilegedWorkload"`
+	// SystemdCgroup enables systemd cgroup support for the runtime.
+	SystemdCgroup bool `toml:"systemd_cgroup" json:"systemdCgroup"`
+	// Snapshotter is the snapshotter used by containers running on the
+	// runtime. The global snapshotter is used if it is not set.
+	Snapshotter string `toml:"snapshotter" json:"snapshotter"`
+	// SandboxImage is the image used by sandbox container running on the
+	// runtime. The global sandbox image is used if it is not set.
+	SandboxImage string `toml:"sandbox_image" json:"sandboxImage"`
+	// CriuPath is the path of the criu binary used by the runtime to
+	// checkpoint and restore containers. criu in $PATH is used if it is
+	// not set.
+	CriuPath string `toml:"criu_path" json:"criuPath"`
+	// TmpfsMounts are the container paths mounted as tmpfs in containers
+	// running on the runtime, e.g. "/run". The existing content of the paths
+	// in the image is copied up into the tmpfs.
+	TmpfsMounts []string `toml:"tmpfs_mounts" json:"tmpfsMounts"`
+	// UserNamespace runs sandboxes on the runtime and their containers in
+	// a user namespace with an ID range allocated from the remap range by
+	// default. It can be overridden by sandbox annotation.
+	UserNamespace bool `toml:"user_namespace" json:"userNamespace"`
 }
 
 // ContainerdConfig contains toml config related to containerd
 type ContainerdConfig struct {
 	// Snapshotter is the snapshotter used by containerd.
 	Snapshotter string `toml:"snapshotter" json:"snapshotter"`
+	// DefaultRuntimeName is the name of the runtime handler used when no
+	// runtime handler is specified by the sandbox.
+	DefaultRuntimeName string `toml:"default_runtime_name" json:"defaultRuntimeName"`
+	// Runtimes is a map from runtime handler name to runtime configuration.
+	// The runtime handler of a sandbox is selected by sandbox annotation.
+	Runtimes map[string]Runtime `toml:"runtimes" json:"runtimes"`
 	// DefaultRuntime is the runtime to use in containerd.
+	// DEPRECATED: use Runtimes and DefaultRuntimeName instead. It is used as
+	// the default runtime handler if that is not configured in Runtimes.
 	DefaultRuntime Runtime `toml:"default_runtime" json:"defaultRuntime"`
 	// UntrustedWorkloadRuntime is a runtime to run untrusted workloads on it.
+	// DEPRECATED: use the "untrusted" runtime handler in Runtimes instead.
 	UntrustedWorkloadRuntime Runtime `toml:"untrusted_workload_runtime" json:"untrustedWorkloadRuntime"`
 }
 
@@ -56,10 +115,40 @@ type Mirror struct {
 	// image auth information is passed by kube itself.
 }
 
+// AuthConfig contains the config related to authentication to a registry.
+type AuthConfig struct {
+	// Username is the username to login the registry.
+	Username string `toml:"username" json:"username"`
+	// Password is the password to login the registry.
+	Password string `toml:"password" json:"password"`
+	// Auth is a base64 encoded string from the concatenation of the username,
+	// a colon, and the password.
+	Auth string `toml:"auth" json:"auth"`
+	// IdentityToken is used to authenticate the user and get
+	// an access token for the registry.
+	IdentityToken string `toml:"identitytoken" json:"identitytoken"`
+}
+
 // Registry is registry settings configured
 type Registry struct {
 	// Mirrors are namespace to mirror mapping for all namespaces.
 	Mirrors map[string]Mirror `toml:"mirrors" json:"mirrors"`
+	// Auths are registry endpoint host to node-level credential mapping. The
+	// credential is used when no credential is specified in the pull request,
+	// e.g. when pulling pinned images.
+	Auths map[string]AuthConfig `toml:"auths" json:"auths"`
+}
+
+// UsernsRemapConfig contains the config related to user namespace remapping.
+type UsernsRemapConfig struct {
+	// Start is the first host UID/GID of the subordinate ID range, from
+	// which the ID ranges of user namespaces are allocated.
+	Start uint32 `toml:"start" json:"start"`
+	// Size is the size of the subordinate ID range. User namespace
+	// remapping is disabled if it is 0.
+	Size uint32 `toml:"size" json:"size"`
+	// PodSize is the size of the ID range allocated to each sandbox.
+	PodSize uint32 `toml:"pod_size" json:"podSize"`
 }
 
 // PluginConfig contains toml config related to CRI plugin,
@@ -81,8 +170,70 @@ type PluginConfig struct {
 	SandboxImage string `toml:"sandbox_image" json:"sandboxImage"`
 	// StatsCollectPeriod is the period (in seconds) of snapshots stats collection.
 	StatsCollectPeriod int `toml:"stats_collect_period" json:"statsCollectPeriod"`
-	// SystemdCgroup enables systemd cgroup support.
+	// ContainerStatsCollectPeriod is the period (in seconds) of container cpu/memory
+	// stats collection. Container stats are fetched from containerd on demand
+	// and are not cached if it is not positive.
+	ContainerStatsCollectPeriod int `toml:"container_stats_collect_period" json:"containerStatsCollectPeriod"`
+	// SystemdCgroup enables systemd cgroup support for all runtimes.
 	SystemdCgroup bool `toml:"systemd_cgroup" json:"systemdCgroup"`
+	// EnableProjectQuota enables filesystem project quota for writable layers
+	// of overlayfs snapshotter, which requires xfs or ext4 mounted with project
+	// quota enabled. Writable layer usage is got from quota accounting instead of
+	// walking the layer. It falls back to walking if project quota is not supported.
+	EnableProjectQuota bool `toml:"enable_project_quota" json:"enableProjectQuota"`
+	// ImageGCPeriod is the period (in seconds) of image garbage collection.
+	// Image garbage collection is disabled if it is not positive.
+	ImageGCPeriod int `toml:"image_gc_period" json:"imageGCPeriod"`
+	// ImageGCHighThresholdPercent is the percent of image filesystem usage
+	// after which image garbage collection is always run.
+	ImageGCHighThresholdPercent int `toml:"image_gc_high_threshold_percent" json:"imageGCHighThresholdPercent"`
+	// ImageGCLowThresholdPercent is the percent of image filesystem usage to
+	// which image garbage collection attempts to free.
+	ImageGCLowThresholdPercent int `toml:"image_gc_low_threshold_percent" json:"imageGCLowThresholdPercent"`
+	// ImageGCMinAge is the minimum age (in seconds) of an image since it is
+	// pulled or first detected before it could be garbage collected.
+	ImageGCMinAge int `toml:"image_gc_min_age" json:"imageGCMinAge"`
+	// PinnedImages are images which are pulled in the background at startup,
+	// and are never removed by RemoveImage or image garbage collection. The
+	// sandbox image is always pinned.
+	PinnedImages []string `toml:"pinned_images" json:"pinnedImages"`
+	// Platforms are the platforms used to select images from manifest lists,
+	// in the order of preference, e.g. ["linux/arm64", "linux/arm/v7"]. The
+	// platform of the node is used if it is empty.
+	Platforms []string `toml:"platforms" json:"platforms"`
+	// VerifyImageContent verifies that contents of images are complete on
+	// restart. Images with missing contents are marked as broken and hidden,
+	// so that they are pulled again.
+	VerifyImageContent bool `toml:"verify_image_content" json:"verifyImageContent"`
+	// UsernsRemap contains config related to user namespace remapping.
+	UsernsRemap UsernsRemapConfig `toml:"userns_remap" json:"usernsRemap"`
+	// SeccompProfileRoot is the directory localhost/ seccomp profiles are
+	// resolved against. Profiles must not escape the directory. The path
+	// following localhost/ is used as is if it is not set.
+	SeccompProfileRoot string `toml:"seccomp_profile_root" json:"seccompProfileRoot"`
+	// DefaultSeccompProfile is the path of the seccomp profile used for
+	// runtime/default and docker/default. The built-in default profile is
+	// used if it is not set.
+	DefaultSeccompProfile string `toml:"default_seccomp_profile" json:"defaultSeccompProfile"`
+	// ApplyDefaultSeccompProfile applies the default seccomp profile to
+	// unprivileged containers and sandboxes which don't specify a seccomp
+	// profile, instead of running them unconfined.
+	ApplyDefaultSeccompProfile bool `toml:"apply_default_seccomp_profile" json:"applyDefaultSeccompProfile"`
+	// ApparmorProfileDir is the directory of apparmor profile files managed
+	// by the CRI plugin. Profiles in the directory are loaded at startup,
+	// reloaded when they change, and unloaded when they are removed.
+	ApparmorProfileDir string `toml:"apparmor_profile_dir" json:"apparmorProfileDir"`
+	// ApparmorDefaultProfileTemplate is the path of the go template used to
+	// generate the default apparmor profile. The built-in default profile is
+	// used if it is not set.
+	ApparmorDefaultProfileTemplate string `toml:"apparmor_default_profile_template" json:"apparmorDefaultProfileTemplate"`
+	// ApparmorProfileSyncPeriod is the period (in seconds) of apparmor profile
+	// directory and template sync.
+	ApparmorProfileSyncPeriod int `toml:"apparmor_profile_sync_period" json:"apparmorProfileSyncPeriod"`
+	// HooksDir is the directory of OCI hook definitions in the hooks.d
+	// format. Matching hooks are injected into container and sandbox specs.
+	// Hooks are loaded at startup.
+	HooksDir string `toml:"hooks_dir" json:"hooksDir"`
 }
 
 // Config contains all configurations for cri server.
@@ -98,6 +249,31 @@ type Config struct {
 	RootDir string `json:"rootDir"`
 	// StateDir is the root directory path for managing volatile pod/container data
 	StateDir string `json:"stateDir"`
+	// Platforms are the parsed PluginConfig.Platforms, or the platform of
+	// the node if it is empty.
+	ParsedPlatforms []imagespec.Platform `json:"parsedPlatforms"`
+}
+
+// redactedCredential replaces the registry credentials in redacted config.
+const redactedCredential = "<redacted>"
+
+// Redacted returns a copy of the config with the registry credentials
+// redacted, so that it could be logged or returned in status.
+func (c Config) Redacted() Config {
+	if c.Registry.Auths == nil {
+		return c
+	}
+	auths := make(map[string]AuthConfig, len(c.Registry.Auths))
+	for host, auth := range c.Registry.Auths {
+		for _, s := range []*string{&auth.Password, &auth.Auth, &auth.IdentityToken} {
+			if *s != "" {
+				*s = redactedCredential
+			}
+		}
+		auths[host] = auth
+	}
+	c.Registry.Auths = auths
+	return c
 }
 
 // DefaultConfig returns default configurations of cri plugin.
@@ -108,19 +284,31 @@ func DefaultConfig() PluginConfig {
 			NetworkPluginConfDir: "/etc/cni/net.d",
 		},
 		ContainerdConfig: ContainerdConfig{
-			Snapshotter: containerd.DefaultSnapshotter,
+			Snapshotter:        containerd.DefaultSnapshotter,
+			DefaultRuntimeName: RuntimeDefault,
 			DefaultRuntime: Runtime{
-				Type:   "io.containerd.runtime.v1.linux",
-				Engine: "",
-				Root:   "",
+				Type:        "io.containerd.runtime.v1.linux",
+				Engine:      "",
+				Root:        "",
+				TmpfsMounts: []string{"/run"},
 			},
 		},
-		StreamServerAddress: "",
-		StreamServerPort:    "10010",
-		EnableSelinux:       false,
-		SandboxImage:        "gcr.io/google_containers/pause:3.1",
-		StatsCollectPeriod:  10,
-		SystemdCgroup:       false,
+		StreamServerAddress:         "",
+		StreamServerPort:            "10010",
+		EnableSelinux:               false,
+		SandboxImage:                "gcr.io/google_containers/pause:3.1",
+		StatsCollectPeriod:          10,
+		ContainerStatsCollectPeriod: 10,
+		SystemdCgroup:               false,
+		ImageGCPeriod:               0,
+		ImageGCHighThresholdPercent: 85,
+		ImageGCLowThresholdPercent:  80,
+		ImageGCMinAge:               120,
+		VerifyImageContent:          true,
+		UsernsRemap: UsernsRemapConfig{
+			PodSize: 65536,
+		},
+		ApparmorProfileSyncPeriod: 10,
 		Registry: Registry{
 			Mirrors: map[string]Mirror{
 				"docker.io": {
@@ -130,3 +318,88 @@ func DefaultConfig() PluginConfig {
 		},
 	}
 }
+
+// ValidatePluginConfig validates the given plugin configuration, and converts
+// deprecated options into the runtime handler configuration.
+func ValidatePluginConfig(c *PluginConfig) error {
+	if c.ContainerdConfig.DefaultRuntimeName == "" {
+		c.ContainerdConfig.DefaultRuntimeName = RuntimeDefault
+	}
+	runtimes := make(map[string]Runtime)
+	for name, r := range c.ContainerdConfig.Runtimes {
+		runtimes[name] = r
+	}
+	// Use the deprecated runtime options if the runtime handlers are not
+	// configured.
+	if _, ok := runtimes[c.ContainerdConfig.DefaultRuntimeName]; !ok && c.ContainerdConfig.DefaultRuntime.Type != "" {
+		runtimes[c.ContainerdConfig.DefaultRuntimeName] = c.ContainerdConfig.DefaultRuntime
+	}
+	if _, ok := runtimes[RuntimeUntrusted]; !ok && c.ContainerdConfig.UntrustedWorkloadRuntime.Type != "" {
+		r := c.ContainerdConfig.UntrustedWorkloadRuntime
+		r.PrivilegedWorkload = PrivilegedWorkloadDeny
+		runtimes[RuntimeUntrusted] = r
+	}
+	if c.SeccompProfileRoot != "" && !filepath.IsAbs(c.SeccompProfileRoot) {
+		return errors.Errorf("seccomp profile root %q is not an absolute path", c.SeccompProfileRoot)
+	}
+	if c.DefaultSeccompProfile != "" && !filepath.IsAbs(c.DefaultSeccompProfile) {
+		return errors.Errorf("default seccomp profile %q is not an absolute path", c.DefaultSeccompProfile)
+	}
+	if c.ApparmorProfileDir != "" && !filepath.IsAbs(c.ApparmorProfileDir) {
+		return errors.Errorf("apparmor profile directory %q is not an absolute path", c.ApparmorProfileDir)
+	}
+	if c.ApparmorDefaultProfileTemplate != "" && !filepath.IsAbs(c.ApparmorDefaultProfileTemplate) {
+		return errors.Errorf("apparmor default profile template %q is not an absolute path", c.ApparmorDefaultProfileTemplate)
+	}
+	if c.HooksDir != "" && !filepath.IsAbs(c.HooksDir) {
+		return errors.Errorf("hooks directory %q is not an absolute path", c.HooksDir)
+	}
+	if (c.ApparmorProfileDir != "" || c.ApparmorDefaultProfileTemplate != "") && c.ApparmorProfileSyncPeriod <= 0 {
+		return errors.Errorf("invalid apparmor profile sync period %d", c.ApparmorProfileSyncPeriod)
+	}
+	if c.UsernsRemap.Size != 0 {
+		if c.UsernsRemap.Start == 0 {
+			return errors.New("userns remap range must not start at 0")
+		}
+		if c.UsernsRemap.PodSize == 0 || c.UsernsRemap.Size < c.UsernsRemap.PodSize {
+			return errors.Errorf("userns remap size %d is smaller than pod size %d",
+				c.UsernsRemap.Size, c.UsernsRemap.PodSize)
+		}
+	}
+	if _, ok := runtimes[c.ContainerdConfig.DefaultRuntimeName]; !ok {
+		return errors.Errorf("default runtime %q is not configured", c.ContainerdConfig.DefaultRuntimeName)
+	}
+	for name, r := range runtimes {
+		if r.Type == "" {
+			return errors.Errorf("runtime type of runtime %q is not set", name)
+		}
+		switch r.PrivilegedWorkload {
+		case "":
+			r.PrivilegedWorkload = PrivilegedWorkloadAllow
+		case PrivilegedWorkloadAllow, PrivilegedWorkloadDeny:
+		default:
+			return errors.Errorf("invalid privileged workload policy %q of runtime %q", r.PrivilegedWorkload, name)
+		}
+		// The global systemd cgroup option applies to all runtimes.
+		if c.SystemdCgroup {
+			r.SystemdCgroup = true
+		}
+		if r.Snapshotter == "" {
+			r.Snapshotter = c.ContainerdConfig.Snapshotter
+		}
+		if r.SandboxImage == "" {
+			r.SandboxImage = c.SandboxImage
+		}
+		for _, p := range r.TmpfsMounts {
+			if !filepath.IsAbs(p) || filepath.Clean(p) == "/" {
+				return errors.Errorf("invalid tmpfs mount %q of runtime %q", p, name)
+			}
+		}
+		if r.UserNamespace && c.UsernsRemap.Size == 0 {
+			return errors.Errorf("runtime %q enables user namespace, but userns remap range is not configured", name)
+		}
+		runtimes[name] = r
+	}
+	c.ContainerdConfig.Runtimes = runtimes
+	return nil
+}
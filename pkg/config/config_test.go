@@ -0,0 +1,285 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedacted(t *testing.T) {
+	secrets := []string{"test-password", "dGVzdC11c2VyOnRlc3QtcGFzc3dvcmQ=", "test-token"}
+	c := Config{PluginConfig: DefaultConfig()}
+	c.Registry.Auths = map[string]AuthConfig{
+		"gcr.io": {
+			Username: "test-user",
+			Password: secrets[0],
+			Auth:     secrets[1],
+		},
+		"quay.io": {IdentityToken: secrets[2]},
+	}
+	redacted := c.Redacted()
+	jsonData, err := json.Marshal(redacted)
+	require.NoError(t, err)
+	for _, logged := range []string{fmt.Sprintf("%+v", redacted), string(jsonData)} {
+		for _, secret := range secrets {
+			assert.NotContains(t, logged, secret)
+		}
+		assert.Contains(t, logged, "test-user", "username should not be redacted")
+	}
+	assert.Equal(t, AuthConfig{IdentityToken: redactedCredential}, redacted.Registry.Auths["quay.io"])
+	assert.Equal(t, secrets[0], c.Registry.Auths["gcr.io"].Password, "original config should not be changed")
+}
+
+func TestValidatePluginConfig(t *testing.T) {
+	const (
+		testType         = "io.containerd.runtime.v1.linux"
+		testSnapshotter  = "overlayfs"
+		testSandboxImage = "gcr.io/google_containers/pause:3.1"
+	)
+	for desc, test := range map[string]struct {
+		config           func(c *PluginConfig)
+		expectErr        bool
+		expectedDefault  string
+		expectedRuntimes map[string]Runtime
+	}{
+		"should convert deprecated default runtime into default runtime handler": {
+			config: func(c *PluginConfig) {
+				c.DefaultRuntimeName = ""
+				c.DefaultRuntime = Runtime{Type: testType, Engine: "runc"}
+			},
+			expectedDefault: RuntimeDefault,
+			expectedRuntimes: map[string]Runtime{
+				RuntimeDefault: {
+					Type:               testType,
+					Engine:             "runc",
+					PrivilegedWorkload: PrivilegedWorkloadAllow,
+					Snapshotter:        testSnapshotter,
+					SandboxImage:       testSandboxImage,
+				},
+			},
+		},
+		"should convert deprecated untrusted workload runtime into untrusted runtime handler": {
+			config: func(c *PluginConfig) {
+				c.DefaultRuntime = Runtime{Type: testType}
+				c.UntrustedWorkloadRuntime = Runtime{Type: testType, Engine: "runsc"}
+			},
+			expectedDefault: RuntimeDefault,
+			expectedRuntimes: map[string]Runtime{
+				RuntimeDefault: {
+					Type:               testType,
+					PrivilegedWorkload: PrivilegedWorkloadAllow,
+					Snapshotter:        testSnapshotter,
+					SandboxImage:       testSandboxImage,
+				},
+				RuntimeUntrusted: {
+					Type:               testType,
+					Engine:             "runsc",
+					PrivilegedWorkload: PrivilegedWorkloadDeny,
+					Snapshotter:        testSnapshotter,
+					SandboxImage:       testSandboxImage,
+				},
+			},
+		},
+		"should not override configured runtime handlers with deprecated runtimes": {
+			config: func(c *PluginConfig) {
+				c.DefaultRuntime = Runtime{Type: testType, Engine: "deprecated"}
+				c.UntrustedWorkloadRuntime = Runtime{Type: testType, Engine: "deprecated"}
+				c.Runtimes = map[string]Runtime{
+					RuntimeDefault:   {Type: testType, Engine: "runc"},
+					RuntimeUntrusted: {Type: testType, Engine: "runsc"},
+				}
+			},
+			expectedDefault: RuntimeDefault,
+			expectedRuntimes: map[string]Runtime{
+				RuntimeDefault: {
+					Type:               testType,
+					Engine:             "runc",
+					PrivilegedWorkload: PrivilegedWorkloadAllow,
+					Snapshotter:        testSnapshotter,
+					SandboxImage:       testSandboxImage,
+				},
+				RuntimeUntrusted: {
+					Type:               testType,
+					Engine:             "runsc",
+					PrivilegedWorkload: PrivilegedWorkloadAllow,
+					Snapshotter:        testSnapshotter,
+					SandboxImage:       testSandboxImage,
+				},
+			},
+		},
+		"should keep per runtime snapshotter and sandbox image and apply global systemd cgroup": {
+			config: func(c *PluginConfig) {
+				c.SystemdCgroup = true
+				c.DefaultRuntimeName = "test-handler"
+				c.Runtimes = map[string]Runtime{
+					"test-handler": {
+						Type:               testType,
+						PrivilegedWorkload: PrivilegedWorkloadDeny,
+						Snapshotter:        "test-snapshotter",
+						SandboxImage:       "test-sandbox-image",
+					},
+				}
+			},
+			expectedDefault: "test-handler",
+			expectedRuntimes: map[string]Runtime{
+				"test-handler": {
+					Type:               testType,
+					PrivilegedWorkload: PrivilegedWorkloadDeny,
+					SystemdCgroup:      true,
+					Snapshotter:        "test-snapshotter",
+					SandboxImage:       "test-sandbox-image",
+				},
+			},
+		},
+		"should fail if default runtime is not configured": {
+			config: func(c *PluginConfig) {
+				c.DefaultRuntimeName = "not-exist"
+				c.DefaultRuntime = Runtime{}
+				c.Runtimes = map[string]Runtime{RuntimeDefault: {Type: testType}}
+			},
+			expectErr: true,
+		},
+		"should fail if neither default runtime handler nor deprecated default runtime is set": {
+			config: func(c *PluginConfig) {
+				c.DefaultRuntime = Runtime{}
+			},
+			expectErr: true,
+		},
+		"should fail if runtime type is not set": {
+			config: func(c *PluginConfig) {
+				c.Runtimes = map[string]Runtime{"test-handler": {}}
+			},
+			expectErr: true,
+		},
+		"should fail on invalid privileged workload policy": {
+			config: func(c *PluginConfig) {
+				c.Runtimes = map[string]Runtime{"test-handler": {Type: testType, PrivilegedWorkload: "invalid"}}
+			},
+			expectErr: true,
+		},
+		"should fail on relative tmpfs mount": {
+			config: func(c *PluginConfig) {
+				c.Runtimes = map[string]Runtime{"test-handler": {Type: testType, TmpfsMounts: []string{"run"}}}
+			},
+			expectErr: true,
+		},
+		"should fail on root tmpfs mount": {
+			config: func(c *PluginConfig) {
+				c.Runtimes = map[string]Runtime{"test-handler": {Type: testType, TmpfsMounts: []string{"/tmp/.."}}}
+			},
+			expectErr: true,
+		},
+		"should fail if runtime enables user namespace without userns remap range": {
+			config: func(c *PluginConfig) {
+				c.Runtimes = map[string]Runtime{"test-handler": {Type: testType, UserNamespace: true}}
+			},
+			expectErr: true,
+		},
+		"should accept runtime with user namespace and userns remap range": {
+			config: func(c *PluginConfig) {
+				c.UsernsRemap = UsernsRemapConfig{Start: 100000, Size: 65536 * 2, PodSize: 65536}
+				c.Runtimes = map[string]Runtime{"test-handler": {Type: testType, UserNamespace: true}}
+			},
+			expectedDefault: RuntimeDefault,
+		},
+		"should fail if userns remap range starts at 0": {
+			config: func(c *PluginConfig) {
+				c.UsernsRemap = UsernsRemapConfig{Size: 65536, PodSize: 65536}
+			},
+			expectErr: true,
+		},
+		"should fail if userns remap range is smaller than pod size": {
+			config: func(c *PluginConfig) {
+				c.UsernsRemap = UsernsRemapConfig{Start: 100000, Size: 1000, PodSize: 65536}
+			},
+			expectErr: true,
+		},
+		"should fail if userns remap pod size is 0": {
+			config: func(c *PluginConfig) {
+				c.UsernsRemap = UsernsRemapConfig{Start: 100000, Size: 65536}
+			},
+			expectErr: true,
+		},
+		"should fail on relative seccomp profile root": {
+			config: func(c *PluginConfig) {
+				c.SeccompProfileRoot = "seccomp"
+			},
+			expectErr: true,
+		},
+		"should fail on relative default seccomp profile": {
+			config: func(c *PluginConfig) {
+				c.DefaultSeccompProfile = "seccomp/default.json"
+			},
+			expectErr: true,
+		},
+		"should fail on relative apparmor profile directory": {
+			config: func(c *PluginConfig) {
+				c.ApparmorProfileDir = "apparmor"
+			},
+			expectErr: true,
+		},
+		"should fail on relative apparmor default profile template": {
+			config: func(c *PluginConfig) {
+				c.ApparmorDefaultProfileTemplate = "apparmor/default"
+			},
+			expectErr: true,
+		},
+		"should fail on invalid apparmor profile sync period": {
+			config: func(c *PluginConfig) {
+				c.ApparmorProfileDir = "/etc/apparmor.d/cri"
+				c.ApparmorProfileSyncPeriod = 0
+			},
+			expectErr: true,
+		},
+		"should fail on relative hooks directory": {
+			config: func(c *PluginConfig) {
+				c.HooksDir = "hooks.d"
+			},
+			expectErr: true,
+		},
+		"should accept absolute profile and hooks paths": {
+			config: func(c *PluginConfig) {
+				c.SeccompProfileRoot = "/etc/seccomp"
+				c.DefaultSeccompProfile = "/etc/seccomp/default.json"
+				c.ApparmorProfileDir = "/etc/apparmor.d/cri"
+				c.ApparmorDefaultProfileTemplate = "/etc/apparmor.d/cri-default"
+				c.HooksDir = "/etc/containers/oci/hooks.d"
+			},
+			expectedDefault: RuntimeDefault,
+		},
+	} {
+		t.Logf("TestCase %q", desc)
+		c := DefaultConfig()
+		c.ContainerdConfig.Snapshotter = testSnapshotter
+		test.config(&c)
+		err := ValidatePluginConfig(&c)
+		if test.expectErr {
+			assert.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, test.expectedDefault, c.DefaultRuntimeName)
+		if test.expectedRuntimes != nil {
+			assert.Equal(t, test.expectedRuntimes, c.Runtimes)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporter
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/containerd/containerd/content"
+	"github.com/docker/distribution/reference"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// Format is the format of the exported archive.
+type Format int
+
+const (
+	// FormatDocker is the format of `docker save`, i.e. Docker Image Spec v1.1.
+	FormatDocker Format = iota
+	// FormatOCI is the OCI Image Layout.
+	FormatOCI
+)
+
+// imageNameAnnotation is the annotation of the full image name, which is
+// read by the importer and containerd.
+const imageNameAnnotation = "io.containerd.image.name"
+
+// Image is an image to export.
+type Image struct {
+	// Names are the references of the image.
+	Names []string
+	// Manifest is the descriptor of the image manifest. The manifest,
+	// config and layers must be in the content store.
+	Manifest ocispec.Descriptor
+}
+
+// manifestDotJSON is an entry in manifest.json of docker archive.
+type manifestDotJSON struct {
+	Config   string
+	RepoTags []string
+	Layers   []string
+}
+
+// Export writes images into a tar archive of the format. Layers are written
+// as they are in the content store, which may be compressed. Both the importer
+// and `docker load` detect layer compression.
+func Export(ctx context.Context, provider content.Provider, w io.Writer, imgs []Image, format Format) error {
+	e := &exporter{
+		provider: provider,
+		tw:       tar.NewWriter(w),
+		written:  make(map[string]bool),
+	}
+	var err error
+	switch format {
+	case FormatDocker:
+		err = e.exportDocker(ctx, imgs)
+	case FormatOCI:
+		err = e.exportOCI(ctx, imgs)
+	default:
+		err = errors.Errorf("unsupported format %d", format)
+	}
+	if err != nil {
+		return err
+	}
+	return e.tw.Close()
+}
+
+// exporter writes files into the tar archive.
+type exporter struct {
+	provider content.Provider
+	tw       *tar.Writer
+	// written is the set of files already written, blobs shared by
+	// images are only written once.
+	written map[string]bool
+}
+
+// exportDocker exports images as a docker archive.
+func (e *exporter) exportDocker(ctx context.Context, imgs []Image) error {
+	var mfsts []manifestDotJSON
+	for _, img := range imgs {
+		manifest, err := e.readManifest(ctx, img.Manifest)
+		if err != nil {
+			return err
+		}
+		mfst := manifestDotJSON{
+			Config:   manifest.Config.Digest.Hex() + ".json",
+			RepoTags: dockerRepoTags(img.Names),
+		}
+		if err := e.writeBlob(ctx, mfst.Config, manifest.Config); err != nil {
+			return err
+		}
+		for _, l := range manifest.Layers {
+			name := l.Digest.Hex() + "/layer.tar"
+			if err := e.writeBlob(ctx, name, l); err != nil {
+				return err
+			}
+			mfst.Layers = append(mfst.Layers, name)
+		}
+		mfsts = append(mfsts, mfst)
+	}
+	return e.writeJSON("manifest.json", mfsts)
+}
+
+// exportOCI exports images as an OCI image layout.
+func (e *exporter) exportOCI(ctx context.Context, imgs []Image) error {
+	if err := e.writeJSON(ocispec.ImageLayoutFile, ocispec.ImageLayout{
+		Version: ocispec.ImageLayoutVersion,
+	}); err != nil {
+		return err
+	}
+	idx := ocispec.Index{
+		Versioned: specs.Versioned{
+			SchemaVersion: 2,
+		},
+	}
+	for _, img := range imgs {
+		manifest, err := e.readManifest(ctx, img.Manifest)
+		if err != nil {
+			return err
+		}
+		for _, desc := range append([]ocispec.Descriptor{img.Manifest, manifest.Config}, manifest.Layers...) {
+			if err := e.writeBlob(ctx, blobName(desc), desc); err != nil {
+				return err
+			}
+		}
+		if len(img.Names) == 0 {
+			// Keep the untagged image in the index, it is skipped by
+			// importers.
+			idx.Manifests = append(idx.Manifests, img.Manifest)
+			continue
+		}
+		for _, name := range img.Names {
+			desc := img.Manifest
+			desc.Annotations = map[string]string{
+				imageNameAnnotation: name,
+			}
+			if tagged, err := reference.ParseNormalizedNamed(name); err == nil {
+				if t, ok := tagged.(reference.Tagged); ok {
+					desc.Annotations[ocispec.AnnotationRefName] = t.Tag()
+				}
+			}
+			idx.Manifests = append(idx.Manifests, desc)
+		}
+	}
+	return e.writeJSON("index.json", idx)
+}
+
+// readManifest reads the image manifest from the content store.
+func (e *exporter) readManifest(ctx context.Context, desc ocispec.Descriptor) (*ocispec.Manifest, error) {
+	b, err := content.ReadBlob(ctx, e.provider, desc.Digest)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read manifest %q", desc.Digest)
+	}
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(b, &manifest); err != nil {
+		return nil, errors.Wrapf(err, "unmarshal manifest %q", desc.Digest)
+	}
+	return &manifest, nil
+}
+
+// writeBlob copies a blob from the content store into the archive.
+func (e *exporter) writeBlob(ctx context.Context, name string, desc ocispec.Descriptor) error {
+	if e.written[name] {
+		return nil
+	}
+	ra, err := e.provider.ReaderAt(ctx, desc.Digest)
+	if err != nil {
+		return errors.Wrapf(err, "get reader for blob %q", desc.Digest)
+	}
+	defer ra.Close()
+	if err := e.writeFile(name, content.NewReader(ra), ra.Size()); err != nil {
+		return errors.Wrapf(err, "write blob %q", desc.Digest)
+	}
+	return nil
+}
+
+// writeJSON writes a json file into the archive.
+func (e *exporter) writeJSON(name string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrapf(err, "marshal %q", name)
+	}
+	if err := e.writeFile(name, bytes.NewReader(b), int64(len(b))); err != nil {
+		return errors.Wrapf(err, "write %q", name)
+	}
+	return nil
+}
+
+// writeFile writes a regular file into the archive.
+func (e *exporter) writeFile(name string, r io.Reader, size int64) error {
+	if err := e.tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0444,
+		Size:     size,
+		ModTime:  time.Unix(0, 0),
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return err
+	}
+	if _, err := io.CopyN(e.tw, r, size); err != nil {
+		return err
+	}
+	e.written[name] = true
+	return nil
+}
+
+// blobName returns the path of the blob in OCI image layout.
+func blobName(desc ocispec.Descriptor) string {
+	return "blobs/" + desc.Digest.Algorithm().String() + "/" + desc.Digest.Hex()
+}
+
+// dockerRepoTags returns tagged references in names, because docker archive
+// doesn't support digested references.
+func dockerRepoTags(names []string) []string {
+	var tags []string
+	for _, name := range names {
+		named, err := reference.ParseNormalizedNamed(name)
+		if err != nil {
+			continue
+		}
+		if _, ok := named.(reference.Canonical); ok {
+			continue
+		}
+		tags = append(tags, reference.FamiliarString(reference.TagNameOnly(named)))
+	}
+	return tags
+}
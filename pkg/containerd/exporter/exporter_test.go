@@ -0,0 +1,183 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporter
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/content/local"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestBlob writes the data into the content store.
+func writeTestBlob(ctx context.Context, t *testing.T, cs content.Ingester, mediaType string, data []byte) ocispec.Descriptor {
+	desc := ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    digest.FromBytes(data),
+		Size:      int64(len(data)),
+	}
+	require.NoError(t, content.WriteBlob(ctx, cs, "test-"+desc.Digest.String(), bytes.NewReader(data), desc.Size, desc.Digest))
+	return desc
+}
+
+// writeTestImage writes an image with a shared layer and a layer of its
+// own into the content store, and returns the manifest.
+func writeTestImage(ctx context.Context, t *testing.T, cs content.Ingester, name string) ocispec.Descriptor {
+	config := writeTestBlob(ctx, t, cs, ocispec.MediaTypeImageConfig, []byte(`{"architecture":"amd64","os":"linux","name":"`+name+`"}`))
+	shared := writeTestBlob(ctx, t, cs, ocispec.MediaTypeImageLayer, []byte("shared-layer"))
+	own := writeTestBlob(ctx, t, cs, ocispec.MediaTypeImageLayer, []byte("layer-"+name))
+	b, err := json.Marshal(ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		Config:    config,
+		Layers:    []ocispec.Descriptor{shared, own},
+	})
+	require.NoError(t, err)
+	return writeTestBlob(ctx, t, cs, ocispec.MediaTypeImageManifest, b)
+}
+
+// readTestArchive returns the files in the archive in order.
+func readTestArchive(t *testing.T, r io.Reader) ([]string, map[string][]byte) {
+	var names []string
+	files := make(map[string][]byte)
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		data, err := ioutil.ReadAll(tr)
+		require.NoError(t, err)
+		names = append(names, hdr.Name)
+		files[hdr.Name] = data
+	}
+	return names, files
+}
+
+func TestExport(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "exporter-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	cs, err := local.NewStore(dir)
+	require.NoError(t, err)
+	manifest1 := writeTestImage(ctx, t, cs, "image-1")
+	manifest2 := writeTestImage(ctx, t, cs, "image-2")
+	imgs := []Image{
+		{
+			Names: []string{
+				"docker.io/library/image-1:latest",
+				"gcr.io/test/image-1@" + manifest1.Digest.String(),
+			},
+			Manifest: manifest1,
+		},
+		{Manifest: manifest2},
+	}
+	readManifest := func(desc ocispec.Descriptor) ocispec.Manifest {
+		b, err := content.ReadBlob(ctx, cs, desc.Digest)
+		require.NoError(t, err)
+		var m ocispec.Manifest
+		require.NoError(t, json.Unmarshal(b, &m))
+		return m
+	}
+	m1, m2 := readManifest(manifest1), readManifest(manifest2)
+
+	t.Logf("should export docker archive")
+	buf := &bytes.Buffer{}
+	require.NoError(t, Export(ctx, cs, buf, imgs, FormatDocker))
+	names, files := readTestArchive(t, buf)
+	assert.Len(t, names, 6, "shared layer should only be written once")
+	assert.Equal(t, "manifest.json", names[len(names)-1])
+	var mfsts []manifestDotJSON
+	require.NoError(t, json.Unmarshal(files["manifest.json"], &mfsts))
+	assert.Equal(t, []manifestDotJSON{
+		{
+			Config:   m1.Config.Digest.Hex() + ".json",
+			RepoTags: []string{"image-1:latest"},
+			Layers:   []string{m1.Layers[0].Digest.Hex() + "/layer.tar", m1.Layers[1].Digest.Hex() + "/layer.tar"},
+		},
+		{
+			Config: m2.Config.Digest.Hex() + ".json",
+			Layers: []string{m2.Layers[0].Digest.Hex() + "/layer.tar", m2.Layers[1].Digest.Hex() + "/layer.tar"},
+		},
+	}, mfsts)
+	for _, m := range []ocispec.Manifest{m1, m2} {
+		b, err := content.ReadBlob(ctx, cs, m.Layers[1].Digest)
+		require.NoError(t, err)
+		assert.Equal(t, b, files[m.Layers[1].Digest.Hex()+"/layer.tar"])
+	}
+
+	t.Logf("should export OCI image layout")
+	buf.Reset()
+	require.NoError(t, Export(ctx, cs, buf, imgs, FormatOCI))
+	names, files = readTestArchive(t, buf)
+	assert.Len(t, names, 9, "shared layer should only be written once")
+	assert.Equal(t, ocispec.ImageLayoutFile, names[0])
+	assert.Equal(t, "index.json", names[len(names)-1])
+	for _, desc := range []ocispec.Descriptor{manifest1, manifest2, m1.Config, m1.Layers[0], m2.Layers[1]} {
+		b, err := content.ReadBlob(ctx, cs, desc.Digest)
+		require.NoError(t, err)
+		assert.Equal(t, b, files["blobs/sha256/"+desc.Digest.Hex()])
+	}
+	var idx ocispec.Index
+	require.NoError(t, json.Unmarshal(files["index.json"], &idx))
+	require.Len(t, idx.Manifests, 3)
+	assert.Equal(t, map[string]string{
+		imageNameAnnotation:       "docker.io/library/image-1:latest",
+		ocispec.AnnotationRefName: "latest",
+	}, idx.Manifests[0].Annotations)
+	assert.Equal(t, map[string]string{
+		imageNameAnnotation: "gcr.io/test/image-1@" + manifest1.Digest.String(),
+	}, idx.Manifests[1].Annotations)
+	assert.Empty(t, idx.Manifests[2].Annotations, "untagged image should be exported without name")
+	assert.Equal(t, manifest2.Digest, idx.Manifests[2].Digest)
+
+	t.Logf("should fail if blob is missing")
+	assert.Error(t, Export(ctx, cs, ioutil.Discard, []Image{{
+		Manifest: ocispec.Descriptor{Digest: digest.FromString("missing")},
+	}}, FormatOCI))
+
+	t.Logf("should fail on unsupported format")
+	assert.Error(t, Export(ctx, cs, ioutil.Discard, imgs, Format(100)))
+}
+
+func TestDockerRepoTags(t *testing.T) {
+	dgst := digest.FromString("test")
+	assert.Equal(t, []string{
+		"busybox:latest",
+		"busybox:1.0",
+		"gcr.io/test/image:latest",
+	}, dockerRepoTags([]string{
+		"docker.io/library/busybox",
+		"busybox:1.0",
+		"gcr.io/test/image",
+		"gcr.io/test/image@" + dgst.String(),
+		"Invalid:Name",
+	}))
+}
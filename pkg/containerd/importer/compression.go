@@ -0,0 +1,100 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b, 0x08}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// decompressStream detects the compression of the archive and returns a
+// reader of the decompressed archive. The reader of an uncompressed archive
+// is returned as is. The returned reader must be closed after use.
+func decompressStream(reader io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(reader)
+	// Peek returns error if the archive is shorter than the magic, leave
+	// it to the tar reader to report.
+	magic, _ := br.Peek(len(zstdMagic))
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, errors.Wrap(err, "create gzip reader")
+		}
+		return &gzipReadCloser{Reader: gr}, nil
+	case bytes.HasPrefix(magic, zstdMagic):
+		// Image layers in the archive are not compressed again, use a
+		// single goroutine to limit memory used by the decoder.
+		zr, err := zstd.NewReader(br, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, errors.Wrap(err, "create zstd reader")
+		}
+		return &zstdReadCloser{Decoder: zr}, nil
+	default:
+		return ioutil.NopCloser(br), nil
+	}
+}
+
+// gzipReadCloser reads the gzip stream to the end on close, so that the
+// checksum at the end of the stream is verified. The tar reader stops at
+// the end of archive marker, before the checksum is read.
+type gzipReadCloser struct {
+	*gzip.Reader
+}
+
+// Close drains the gzip stream and returns the checksum error if any. It
+// is safe to call Close more than once.
+func (g *gzipReadCloser) Close() error {
+	if _, err := io.Copy(ioutil.Discard, g.Reader); err != nil {
+		return errors.Wrap(err, "read gzip stream")
+	}
+	return g.Reader.Close()
+}
+
+// zstdReadCloser reads the zstd stream to the end on close, so that the
+// checksum of the last frame is verified, and releases the decoder.
+type zstdReadCloser struct {
+	*zstd.Decoder
+	closed bool
+	err    error
+}
+
+// Close drains the zstd stream and returns the checksum error if any. It
+// is safe to call Close more than once, the decoder can't be read after
+// it is closed.
+func (z *zstdReadCloser) Close() error {
+	if z.closed {
+		return z.err
+	}
+	z.closed = true
+	if _, err := io.Copy(ioutil.Discard, z.Decoder); err != nil {
+		z.err = errors.Wrap(err, "read zstd stream")
+	}
+	z.Decoder.Close()
+	return z.err
+}
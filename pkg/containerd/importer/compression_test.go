@@ -0,0 +1,114 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecompressStream(t *testing.T) {
+	data := makeTestArchive(t, []testFile{{name: "test", data: []byte("test-data")}})
+	gzipData := func() []byte {
+		buf := &bytes.Buffer{}
+		w := gzip.NewWriter(buf)
+		_, err := w.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		return buf.Bytes()
+	}()
+	zstdData := func() []byte {
+		w, err := zstd.NewWriter(nil)
+		require.NoError(t, err)
+		defer w.Close()
+		return w.EncodeAll(data, nil)
+	}()
+	// corrupt flips a byte in the checksum at the end of the stream.
+	corrupt := func(b []byte, offset int) []byte {
+		c := append([]byte{}, b...)
+		c[len(c)-offset] ^= 0xff
+		return c
+	}
+	for desc, test := range map[string]struct {
+		input     []byte
+		expected  []byte
+		expectErr bool
+		// expectChecksumErr means the checksum error should be returned
+		// when the stream is read or closed.
+		expectChecksumErr bool
+	}{
+		"should return uncompressed archive as is": {
+			input:    data,
+			expected: data,
+		},
+		"should return input shorter than magic as is": {
+			input:    []byte{0x1f},
+			expected: []byte{0x1f},
+		},
+		"should decompress gzip archive": {
+			input:    gzipData,
+			expected: data,
+		},
+		"should decompress zstd archive": {
+			input:    zstdData,
+			expected: data,
+		},
+		"should fail on invalid gzip header": {
+			input:     append(append([]byte{}, gzipData[:3]...), 0xff),
+			expectErr: true,
+		},
+		// The last 8 bytes of gzip are the CRC-32 and the size.
+		"should fail if gzip checksum doesn't match": {
+			input:             corrupt(gzipData, 8),
+			expectChecksumErr: true,
+		},
+		// The last 4 bytes of the zstd frame are the content checksum.
+		"should fail if zstd checksum doesn't match": {
+			input:             corrupt(zstdData, 1),
+			expectChecksumErr: true,
+		},
+	} {
+		t.Logf("TestCase %q", desc)
+		rc, err := decompressStream(bytes.NewReader(test.input))
+		if test.expectErr {
+			assert.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		if test.expectChecksumErr {
+			// Read like the tar reader, which stops at the end of
+			// archive marker before the end of the stream.
+			_, readErr := ioutil.ReadAll(io.LimitReader(rc, int64(len(data)-1024)))
+			closeErr := rc.Close()
+			assert.True(t, readErr != nil || closeErr != nil)
+			assert.Equal(t, closeErr != nil, rc.Close() != nil, "close should return the same result again")
+			continue
+		}
+		got, err := ioutil.ReadAll(rc)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, got)
+		assert.NoError(t, rc.Close())
+		assert.NoError(t, rc.Close())
+	}
+}
@@ -70,13 +70,17 @@ type imageConfig struct {
 	img  ocispec.Image
 }
 
-// Import implements Docker Image Spec v1.1.
-// An image MUST have `manifest.json`.
+// Import implements Docker Image Spec v1.1 and OCI Image Layout.
+// The archive may be compressed with gzip or zstd.
+// A Docker image archive MUST have `manifest.json`.
 // `repositories` file in Docker Image Spec v1.0 is not supported (yet).
 // Also, the current implementation assumes the implicit file name convention,
 // which is not explicitly documented in the spec. (e.g. deadbeef/layer.tar)
+// An OCI image layout archive MUST have `oci-layout` and `index.json`. Images
+// are tagged with their reference name annotations in `index.json`, and the
+// manifest of the first matching platform is imported for multi-platform images.
 // It returns a group of image references successfully loaded.
-func Import(ctx context.Context, client *containerd.Client, reader io.Reader) (_ []string, retErr error) {
+func Import(ctx context.Context, client *containerd.Client, reader io.Reader, platformList []ocispec.Platform) ([]string, error) {
 	ctx, done, err := client.WithLease(ctx)
 	if err != nil {
 		return nil, err
@@ -84,14 +88,25 @@ func Import(ctx context.Context, client *containerd.Client, reader io.Reader) (_
 	// TODO(random-liu): Fix this after containerd client is fixed (containerd/containerd#2193)
 	defer done() // nolint: errcheck
 
-	cs := client.ContentStore()
-	is := client.ImageService()
+	return importArchive(ctx, client.ContentStore(), client.ImageService(), reader, platformList)
+}
 
-	tr := tar.NewReader(reader)
+// importArchive imports the archive into the content store and the image
+// store, and returns a group of image references successfully loaded.
+func importArchive(ctx context.Context, cs content.Store, is images.Store, reader io.Reader,
+	platformList []ocispec.Platform) (_ []string, retErr error) {
+	rc, err := decompressStream(reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "decompress archive")
+	}
+	defer rc.Close() // nolint: errcheck
+	tr := tar.NewReader(rc)
 	var (
-		mfsts   []manifestDotJSON
-		layers  = make(map[string]ocispec.Descriptor) // key: filename (deadbeeddeadbeef/layer.tar)
-		configs = make(map[string]imageConfig)        // key: filename (deadbeeddeadbeef.json)
+		mfsts     []manifestDotJSON
+		layers    = make(map[string]ocispec.Descriptor) // key: filename (deadbeeddeadbeef/layer.tar)
+		configs   = make(map[string]imageConfig)        // key: filename (deadbeeddeadbeef.json)
+		ociLayout bool
+		ociIndex  *ocispec.Index
 	)
 	for {
 		hdr, err := tr.Next()
@@ -111,6 +126,23 @@ func Import(ctx context.Context, client *containerd.Client, reader io.Reader) (_
 			}
 			continue
 		}
+		if hdr.Name == ociLayoutFile {
+			ociLayout = true
+			continue
+		}
+		if hdr.Name == ociIndexFile {
+			ociIndex, err = onUntarIndexJSON(tr)
+			if err != nil {
+				return nil, errors.Wrapf(err, "untar index %q", hdr.Name)
+			}
+			continue
+		}
+		if isBlob(hdr.Name) {
+			if err := onUntarBlob(ctx, tr, cs, hdr.Name, hdr.Size); err != nil {
+				return nil, errors.Wrapf(err, "untar blob %q", hdr.Name)
+			}
+			continue
+		}
 		if isLayerTar(hdr.Name) {
 			desc, err := onUntarLayerTar(ctx, tr, cs, hdr.Name, hdr.Size)
 			if err != nil {
@@ -128,6 +160,11 @@ func Import(ctx context.Context, client *containerd.Client, reader io.Reader) (_
 			continue
 		}
 	}
+	// Close the decompressed stream before creating images, so that
+	// decompression errors are not ignored.
+	if err := rc.Close(); err != nil {
+		return nil, err
+	}
 	var refs []string
 	defer func() {
 		if retErr == nil {
@@ -145,6 +182,23 @@ func Import(ctx context.Context, client *containerd.Client, reader io.Reader) (_
 			}()
 		}
 	}()
+	if ociLayout {
+		if ociIndex == nil {
+			return nil, errors.Errorf("%q not found in OCI image layout", ociIndexFile)
+		}
+		imgs, err := resolveOCIImages(ctx, cs, *ociIndex, platformList)
+		if err != nil {
+			return nil, errors.Wrap(err, "resolve images in OCI image layout")
+		}
+		for _, img := range imgs {
+			ref, err := createImage(ctx, is, img.name, img.target)
+			if err != nil {
+				return refs, err
+			}
+			refs = append(refs, ref)
+		}
+		return refs, nil
+	}
 	for _, mfst := range mfsts {
 		config, ok := configs[mfst.Config]
 		if !ok {
@@ -160,24 +214,9 @@ func Import(ctx context.Context, client *containerd.Client, reader io.Reader) (_
 		}
 
 		for _, ref := range mfst.RepoTags {
-			normalized, err := util.NormalizeImageRef(ref)
+			ref, err = createImage(ctx, is, ref, *desc)
 			if err != nil {
-				return refs, errors.Wrapf(err, "normalize image ref %q", ref)
-			}
-			ref = normalized.String()
-			imgrec := images.Image{
-				Name:   ref,
-				Target: *desc,
-			}
-			if _, err := is.Create(ctx, imgrec); err != nil {
-				if !errdefs.IsAlreadyExists(err) {
-					return refs, errors.Wrapf(err, "create image ref %+v", imgrec)
-				}
-
-				_, err := is.Update(ctx, imgrec)
-				if err != nil {
-					return refs, errors.Wrapf(err, "update image ref %+v", imgrec)
-				}
+				return refs, err
 			}
 			refs = append(refs, ref)
 		}
@@ -185,6 +224,31 @@ func Import(ctx context.Context, client *containerd.Client, reader io.Reader) (_
 	return refs, nil
 }
 
+// createImage creates or updates the image with normalized reference, and
+// returns the normalized reference.
+func createImage(ctx context.Context, is images.Store, ref string, target ocispec.Descriptor) (string, error) {
+	normalized, err := util.NormalizeImageRef(ref)
+	if err != nil {
+		return "", errors.Wrapf(err, "normalize image ref %q", ref)
+	}
+	ref = normalized.String()
+	imgrec := images.Image{
+		Name:   ref,
+		Target: target,
+	}
+	if _, err := is.Create(ctx, imgrec); err != nil {
+		if !errdefs.IsAlreadyExists(err) {
+			return "", errors.Wrapf(err, "create image ref %+v", imgrec)
+		}
+
+		_, err := is.Update(ctx, imgrec)
+		if err != nil {
+			return "", errors.Wrapf(err, "update image ref %+v", imgrec)
+		}
+	}
+	return ref, nil
+}
+
 func makeDockerSchema2Manifest(mfst manifestDotJSON, config imageConfig, layers map[string]ocispec.Descriptor) (*ocispec.Manifest, error) {
 	manifest := ocispec.Manifest{
 		Versioned: specs.Versioned{
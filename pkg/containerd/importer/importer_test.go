@@ -0,0 +1,473 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/content/local"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/containerd/cri/pkg/containerd/exporter"
+)
+
+// memoryLabelStore is an in-memory label store of the content store.
+type memoryLabelStore struct {
+	lock   sync.Mutex
+	labels map[digest.Digest]map[string]string
+}
+
+func newMemoryLabelStore() local.LabelStore {
+	return &memoryLabelStore{labels: make(map[digest.Digest]map[string]string)}
+}
+
+func (m *memoryLabelStore) Get(d digest.Digest) (map[string]string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.labels[d], nil
+}
+
+func (m *memoryLabelStore) Set(d digest.Digest, labels map[string]string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.labels[d] = labels
+	return nil
+}
+
+func (m *memoryLabelStore) Update(d digest.Digest, update map[string]string) (map[string]string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	labels := make(map[string]string)
+	for k, v := range m.labels[d] {
+		labels[k] = v
+	}
+	for k, v := range update {
+		if v == "" {
+			delete(labels, k)
+		} else {
+			labels[k] = v
+		}
+	}
+	m.labels[d] = labels
+	return labels, nil
+}
+
+// memoryImageStore is an in-memory image store.
+type memoryImageStore struct {
+	images map[string]images.Image
+}
+
+func newMemoryImageStore() *memoryImageStore {
+	return &memoryImageStore{images: make(map[string]images.Image)}
+}
+
+func (m *memoryImageStore) Get(ctx context.Context, name string) (images.Image, error) {
+	i, ok := m.images[name]
+	if !ok {
+		return images.Image{}, errors.Wrapf(errdefs.ErrNotFound, "image %q", name)
+	}
+	return i, nil
+}
+
+func (m *memoryImageStore) List(ctx context.Context, filters ...string) ([]images.Image, error) {
+	var res []images.Image
+	for _, i := range m.images {
+		res = append(res, i)
+	}
+	return res, nil
+}
+
+func (m *memoryImageStore) Create(ctx context.Context, image images.Image) (images.Image, error) {
+	if _, ok := m.images[image.Name]; ok {
+		return images.Image{}, errors.Wrapf(errdefs.ErrAlreadyExists, "image %q", image.Name)
+	}
+	m.images[image.Name] = image
+	return image, nil
+}
+
+func (m *memoryImageStore) Update(ctx context.Context, image images.Image, fieldpaths ...string) (images.Image, error) {
+	if _, ok := m.images[image.Name]; !ok {
+		return images.Image{}, errors.Wrapf(errdefs.ErrNotFound, "image %q", image.Name)
+	}
+	m.images[image.Name] = image
+	return image, nil
+}
+
+func (m *memoryImageStore) Delete(ctx context.Context, name string, opts ...images.DeleteOpt) error {
+	delete(m.images, name)
+	return nil
+}
+
+// newTestStores creates a content store in a temporary directory and an
+// in-memory image store.
+func newTestStores(t *testing.T) (content.Store, *memoryImageStore, func()) {
+	dir, err := ioutil.TempDir("", "importer-test")
+	require.NoError(t, err)
+	cs, err := local.NewLabeledStore(dir, newMemoryLabelStore())
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatalf("Failed to create content store: %v", err)
+	}
+	return cs, newMemoryImageStore(), func() { os.RemoveAll(dir) }
+}
+
+// testFile is a file in a test archive.
+type testFile struct {
+	name string
+	data []byte
+}
+
+// makeTestArchive makes an in-memory tar archive with the files in order.
+func makeTestArchive(t *testing.T, files []testFile) []byte {
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	for _, f := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     f.name,
+			Mode:     0644,
+			Size:     int64(len(f.data)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write(f.data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	return buf.Bytes()
+}
+
+// testBlob is a blob in a test OCI image layout.
+type testBlob struct {
+	desc ocispec.Descriptor
+	data []byte
+}
+
+// file returns the archive file of the blob.
+func (b testBlob) file() testFile {
+	return testFile{name: "blobs/sha256/" + b.desc.Digest.Hex(), data: b.data}
+}
+
+// newTestBlob marshals v if it is not []byte, and returns the blob.
+func newTestBlob(t *testing.T, mediaType string, v interface{}) testBlob {
+	data, ok := v.([]byte)
+	if !ok {
+		var err error
+		data, err = json.Marshal(v)
+		require.NoError(t, err)
+	}
+	return testBlob{
+		desc: ocispec.Descriptor{
+			MediaType: mediaType,
+			Digest:    digest.FromBytes(data),
+			Size:      int64(len(data)),
+		},
+		data: data,
+	}
+}
+
+// newTestManifest returns the config, layer and manifest blobs of an image
+// of the platform.
+func newTestManifest(t *testing.T, platform ocispec.Platform) []testBlob {
+	config := newTestBlob(t, ocispec.MediaTypeImageConfig, ocispec.Image{
+		Architecture: platform.Architecture,
+		OS:           platform.OS,
+		RootFS:       ocispec.RootFS{Type: "layers"},
+	})
+	layer := newTestBlob(t, ocispec.MediaTypeImageLayer, []byte("layer-"+platform.Architecture))
+	manifest := newTestBlob(t, ocispec.MediaTypeImageManifest, ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		Config:    config.desc,
+		Layers:    []ocispec.Descriptor{layer.desc},
+	})
+	return []testBlob{config, layer, manifest}
+}
+
+// newTestIndex returns the index blob of the manifests.
+func newTestIndex(t *testing.T, manifests ...ocispec.Descriptor) testBlob {
+	return newTestBlob(t, ocispec.MediaTypeImageIndex, ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		Manifests: manifests,
+	})
+}
+
+// withAnnotations returns a copy of the descriptor with the annotations.
+func withAnnotations(desc ocispec.Descriptor, annotations map[string]string) ocispec.Descriptor {
+	desc.Annotations = annotations
+	return desc
+}
+
+// withPlatform returns a copy of the descriptor with the platform.
+func withPlatform(desc ocispec.Descriptor, platform ocispec.Platform) ocispec.Descriptor {
+	desc.Platform = &platform
+	return desc
+}
+
+func TestImportOCILayout(t *testing.T) {
+	amd64 := ocispec.Platform{OS: "linux", Architecture: "amd64"}
+	arm64 := ocispec.Platform{OS: "linux", Architecture: "arm64"}
+	amd64Blobs := newTestManifest(t, amd64)
+	amd64Manifest := amd64Blobs[2]
+	arm64Blobs := newTestManifest(t, arm64)
+	arm64Manifest := arm64Blobs[2]
+	multiIndex := newTestIndex(t, withPlatform(arm64Manifest.desc, arm64), withPlatform(amd64Manifest.desc, amd64))
+	arm64Index := newTestIndex(t, withPlatform(arm64Manifest.desc, arm64))
+
+	layoutFile := testFile{name: ociLayoutFile, data: []byte(`{"imageLayoutVersion":"1.0.0"}`)}
+	indexFile := func(manifests ...ocispec.Descriptor) testFile {
+		return testFile{name: ociIndexFile, data: newTestIndex(t, manifests...).data}
+	}
+	blobFiles := func(blobs ...testBlob) []testFile {
+		var files []testFile
+		for _, b := range blobs {
+			files = append(files, b.file())
+		}
+		return files
+	}
+	named := func(desc ocispec.Descriptor, key, name string) ocispec.Descriptor {
+		return withAnnotations(desc, map[string]string{key: name})
+	}
+	allBlobs := blobFiles(append(append(amd64Blobs, arm64Blobs...), multiIndex, arm64Index)...)
+
+	for desc, test := range map[string]struct {
+		files []testFile
+		// expected maps expected image reference to its target digest.
+		expected  map[string]digest.Digest
+		expectErr bool
+	}{
+		"should import image named by containerd image name annotation": {
+			files: append([]testFile{layoutFile,
+				indexFile(withAnnotations(amd64Manifest.desc, map[string]string{
+					imageNameAnnotation:       "docker.io/library/busybox:latest",
+					ocispec.AnnotationRefName: "latest",
+				})),
+			}, allBlobs...),
+			expected: map[string]digest.Digest{
+				"docker.io/library/busybox:latest": amd64Manifest.desc.Digest,
+			},
+		},
+		"should import image named by full reference name annotation": {
+			files: append([]testFile{layoutFile,
+				indexFile(named(amd64Manifest.desc, ocispec.AnnotationRefName, "gcr.io/test/image:v1")),
+			}, allBlobs...),
+			expected: map[string]digest.Digest{
+				"gcr.io/test/image:v1": amd64Manifest.desc.Digest,
+			},
+		},
+		"should skip images with tag-only or no reference name": {
+			files: append([]testFile{layoutFile,
+				indexFile(
+					named(amd64Manifest.desc, ocispec.AnnotationRefName, "latest"),
+					named(amd64Manifest.desc, ocispec.AnnotationRefName, "busybox"),
+					amd64Manifest.desc,
+					named(amd64Manifest.desc, ocispec.AnnotationRefName, "busybox:1.0"),
+				),
+			}, allBlobs...),
+			expected: map[string]digest.Digest{
+				"docker.io/library/busybox:1.0": amd64Manifest.desc.Digest,
+			},
+		},
+		"should select manifest of matching platform in image index": {
+			files: append([]testFile{layoutFile,
+				indexFile(named(multiIndex.desc, imageNameAnnotation, "docker.io/library/busybox:multi")),
+			}, allBlobs...),
+			expected: map[string]digest.Digest{
+				"docker.io/library/busybox:multi": amd64Manifest.desc.Digest,
+			},
+		},
+		"should skip image without matching platform": {
+			files: append([]testFile{layoutFile,
+				indexFile(
+					named(arm64Index.desc, imageNameAnnotation, "docker.io/library/busybox:arm64"),
+					named(multiIndex.desc, imageNameAnnotation, "docker.io/library/busybox:multi"),
+				),
+			}, allBlobs...),
+			expected: map[string]digest.Digest{
+				"docker.io/library/busybox:multi": amd64Manifest.desc.Digest,
+			},
+		},
+		"should not import as OCI image layout without oci-layout file": {
+			files: append([]testFile{
+				indexFile(named(amd64Manifest.desc, imageNameAnnotation, "docker.io/library/busybox:latest")),
+			}, allBlobs...),
+			expected: map[string]digest.Digest{},
+		},
+		"should fail if index.json is missing in OCI image layout": {
+			files:     append([]testFile{layoutFile}, allBlobs...),
+			expectErr: true,
+		},
+		"should fail if blob of image is missing": {
+			files: append([]testFile{layoutFile,
+				indexFile(named(amd64Manifest.desc, imageNameAnnotation, "docker.io/library/busybox:latest")),
+			}, blobFiles(amd64Blobs[0], amd64Manifest)...),
+			expectErr: true,
+		},
+		"should ignore files not in the form of blobs/<algorithm>/<encoded>": {
+			files: append([]testFile{layoutFile,
+				indexFile(named(amd64Manifest.desc, imageNameAnnotation, "docker.io/library/busybox:latest")),
+				{name: "blobs/README", data: []byte("readme")},
+				{name: "blobs/sha256/" + amd64Manifest.desc.Digest.Hex() + "/extra", data: []byte("extra")},
+			}, allBlobs...),
+			expected: map[string]digest.Digest{
+				"docker.io/library/busybox:latest": amd64Manifest.desc.Digest,
+			},
+		},
+		"should fail on blob with invalid digest": {
+			files: append([]testFile{layoutFile,
+				indexFile(named(amd64Manifest.desc, imageNameAnnotation, "docker.io/library/busybox:latest")),
+				{name: "blobs/sha256/invalid", data: []byte("invalid")},
+			}, allBlobs...),
+			expectErr: true,
+		},
+		"should fail on blob with unsupported digest algorithm": {
+			files: append([]testFile{layoutFile,
+				indexFile(named(amd64Manifest.desc, imageNameAnnotation, "docker.io/library/busybox:latest")),
+				{name: "blobs/md5/" + amd64Manifest.desc.Digest.Hex(), data: amd64Manifest.data},
+			}, allBlobs...),
+			expectErr: true,
+		},
+		"should fail on blob not matching its digest": {
+			files: append([]testFile{layoutFile,
+				indexFile(named(amd64Manifest.desc, imageNameAnnotation, "docker.io/library/busybox:latest")),
+				{name: "blobs/sha256/" + amd64Manifest.desc.Digest.Hex(), data: []byte("corrupted")},
+			}, blobFiles(amd64Blobs[0], amd64Blobs[1])...),
+			expectErr: true,
+		},
+	} {
+		t.Logf("TestCase %q", desc)
+		cs, is, cleanup := newTestStores(t)
+		refs, err := importArchive(context.Background(), cs, is,
+			bytes.NewReader(makeTestArchive(t, test.files)), []ocispec.Platform{amd64})
+		if test.expectErr {
+			assert.Error(t, err)
+			assert.Empty(t, is.images, "imported images should be removed on error")
+			cleanup()
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Len(t, refs, len(test.expected))
+		targets := make(map[string]digest.Digest)
+		for name, i := range is.images {
+			targets[name] = i.Target.Digest
+		}
+		assert.Equal(t, test.expected, targets)
+		cleanup()
+	}
+}
+
+func TestIsFullImageRef(t *testing.T) {
+	for name, expected := range map[string]bool{
+		"latest":               false,
+		"busybox":              false,
+		"v1.0":                 false,
+		"Invalid:Name":         false,
+		"busybox:latest":       true,
+		"library/busybox":      true,
+		"gcr.io/test/image:v1": true,
+		"busybox@" + digest.FromString("").String(): true,
+	} {
+		t.Logf("TestCase %q", name)
+		assert.Equal(t, expected, isFullImageRef(name))
+	}
+}
+
+func TestImportExportedArchive(t *testing.T) {
+	ctx := context.Background()
+	platform := ocispec.Platform{OS: "linux", Architecture: "amd64"}
+	blobs := newTestManifest(t, platform)
+	config, layer, manifest := blobs[0], blobs[1], blobs[2]
+	src, _, cleanupSrc := newTestStores(t)
+	defer cleanupSrc()
+	for _, b := range blobs {
+		require.NoError(t, content.WriteBlob(ctx, src, "test-"+b.desc.Digest.String(),
+			bytes.NewReader(b.data), b.desc.Size, b.desc.Digest))
+	}
+	names := []string{
+		"docker.io/library/busybox:latest",
+		"gcr.io/test/image:v1",
+		"gcr.io/test/image@" + manifest.desc.Digest.String(),
+	}
+
+	for desc, test := range map[string]struct {
+		format exporter.Format
+		// expectedNames are the names of imported images.
+		expectedNames []string
+		// sameManifest is true if the manifest is imported as is.
+		sameManifest bool
+	}{
+		"should import exported docker archive": {
+			format: exporter.FormatDocker,
+			// Docker archive doesn't support digested references.
+			expectedNames: names[:2],
+		},
+		"should import exported OCI image layout": {
+			format:        exporter.FormatOCI,
+			expectedNames: names,
+			sameManifest:  true,
+		},
+	} {
+		t.Logf("TestCase %q", desc)
+		buf := &bytes.Buffer{}
+		require.NoError(t, exporter.Export(ctx, src, buf, []exporter.Image{
+			{Names: names, Manifest: manifest.desc},
+		}, test.format))
+
+		cs, is, cleanup := newTestStores(t)
+		refs, err := importArchive(ctx, cs, is, buf, []ocispec.Platform{platform})
+		require.NoError(t, err)
+		assert.Len(t, refs, len(test.expectedNames))
+		for _, name := range test.expectedNames {
+			img, ok := is.images[name]
+			if !assert.True(t, ok, "image %q should be imported", name) {
+				continue
+			}
+			if test.sameManifest {
+				assert.Equal(t, manifest.desc.Digest, img.Target.Digest)
+			}
+			b, err := content.ReadBlob(ctx, cs, img.Target.Digest)
+			require.NoError(t, err)
+			var m ocispec.Manifest
+			require.NoError(t, json.Unmarshal(b, &m))
+			assert.Equal(t, config.desc.Digest, m.Config.Digest)
+			require.Len(t, m.Layers, 1)
+			assert.Equal(t, layer.desc.Digest, m.Layers[0].Digest)
+			for _, b := range blobs {
+				if !test.sameManifest && b.desc.Digest == manifest.desc.Digest {
+					// The manifest is regenerated from docker archive.
+					continue
+				}
+				got, err := content.ReadBlob(ctx, cs, b.desc.Digest)
+				require.NoError(t, err)
+				assert.Equal(t, b.data, got)
+			}
+		}
+		cleanup()
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/log"
+	"github.com/docker/distribution/reference"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+
+	ctrdutil "github.com/containerd/cri/pkg/containerd/util"
+)
+
+// This implements import of OCI Image Layout archives, see
+// https://github.com/opencontainers/image-spec/blob/master/image-layout.md.
+
+const (
+	// ociLayoutFile is the name of the OCI layout marker file.
+	ociLayoutFile = "oci-layout"
+	// ociIndexFile is the name of the OCI layout index file.
+	ociIndexFile = "index.json"
+	// imageNameAnnotation is the annotation of the full image name, which
+	// is set by containerd and buildkit. It takes precedence over
+	// ocispec.AnnotationRefName, which may only be a tag.
+	imageNameAnnotation = "io.containerd.image.name"
+)
+
+// ociImage is an image found in the OCI layout index.
+type ociImage struct {
+	// name is the image reference.
+	name string
+	// target is the manifest of the selected platform.
+	target ocispec.Descriptor
+}
+
+// isBlob returns true if name is like "blobs/sha256/deadbeef".
+func isBlob(name string) bool {
+	parts := strings.Split(name, "/")
+	return len(parts) == 3 && parts[0] == "blobs"
+}
+
+// onUntarIndexJSON parses the OCI layout index.
+func onUntarIndexJSON(r io.Reader) (*ocispec.Index, error) {
+	// name: "index.json"
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var idx ocispec.Index
+	if err := json.Unmarshal(b, &idx); err != nil {
+		return nil, err
+	}
+	return &idx, nil
+}
+
+// onUntarBlob writes a blob into the content store, the content is
+// verified against the digest in the name.
+func onUntarBlob(ctx context.Context, r io.Reader, cs content.Ingester, name string, size int64) error {
+	// name is like "blobs/sha256/deadbeef" ( guaranteed by isBlob() )
+	parts := strings.Split(name, "/")
+	dgst, err := digest.Parse(parts[1] + ":" + parts[2])
+	if err != nil {
+		return errors.Wrap(err, "invalid blob digest")
+	}
+	return content.WriteBlob(ctx, cs, "blob-"+dgst.String(), r, size, dgst)
+}
+
+// resolveOCIImages resolves images in the OCI layout index. For multi-platform
+// images, the manifest of the first matching platform is used. Images without
+// a full reference name or a matching platform are skipped.
+func resolveOCIImages(ctx context.Context, cs content.Store, idx ocispec.Index,
+	platformList []ocispec.Platform) ([]ociImage, error) {
+	var imgs []ociImage
+	for _, desc := range idx.Manifests {
+		name := desc.Annotations[imageNameAnnotation]
+		if name == "" {
+			name = desc.Annotations[ocispec.AnnotationRefName]
+			if name != "" && !isFullImageRef(name) {
+				log.G(ctx).Warnf("Skip image %q with reference name %q without repository", desc.Digest, name)
+				continue
+			}
+		}
+		if name == "" {
+			log.G(ctx).Warnf("Skip image %q without reference name", desc.Digest)
+			continue
+		}
+		target, err := ctrdutil.ResolveManifest(ctx, cs, desc, platformList)
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				log.G(ctx).WithError(err).Warnf("Skip image %q without matching manifest", name)
+				continue
+			}
+			return nil, errors.Wrapf(err, "resolve manifest for %q", name)
+		}
+		if err := checkManifest(ctx, cs, target); err != nil {
+			return nil, errors.Wrapf(err, "check manifest for %q", name)
+		}
+		imgs = append(imgs, ociImage{name: name, target: target})
+	}
+	return imgs, nil
+}
+
+// isFullImageRef returns true if the reference name contains a repository,
+// i.e. it is not only a tag like "latest". A name with neither a path nor a
+// tag or digest is considered a tag.
+func isFullImageRef(name string) bool {
+	named, err := reference.ParseNormalizedNamed(name)
+	if err != nil {
+		return false
+	}
+	if strings.Contains(name, "/") {
+		return true
+	}
+	_, tagged := named.(reference.Tagged)
+	_, digested := named.(reference.Digested)
+	return tagged || digested
+}
+
+// checkManifest makes sure the config and layers of the manifest are imported,
+// and labels the manifest so that they are not garbage collected.
+func checkManifest(ctx context.Context, cs content.Store, desc ocispec.Descriptor) error {
+	b, err := content.ReadBlob(ctx, cs, desc.Digest)
+	if err != nil {
+		return errors.Wrapf(err, "read manifest %q", desc.Digest)
+	}
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(b, &manifest); err != nil {
+		return errors.Wrapf(err, "unmarshal manifest %q", desc.Digest)
+	}
+	info := content.Info{
+		Digest: desc.Digest,
+		Labels: make(map[string]string),
+	}
+	var fieldpaths []string
+	for i, d := range append([]ocispec.Descriptor{manifest.Config}, manifest.Layers...) {
+		if _, err := cs.Info(ctx, d.Digest); err != nil {
+			return errors.Wrapf(err, "blob %q not found", d.Digest)
+		}
+		key := fmt.Sprintf("containerd.io/gc.ref.content.%d", i)
+		info.Labels[key] = d.Digest.String()
+		fieldpaths = append(fieldpaths, "labels."+key)
+	}
+	if _, err := cs.Update(ctx, info, fieldpaths...); err != nil {
+		return errors.Wrapf(err, "update labels of manifest %q", desc.Digest)
+	}
+	return nil
+}
@@ -18,15 +18,18 @@ package opts
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"syscall"
 
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/containers"
 	"github.com/containerd/containerd/errdefs"
 	"github.com/containerd/containerd/mount"
 	"github.com/containerd/continuity/fs"
+	"github.com/opencontainers/image-spec/identity"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
@@ -125,3 +128,118 @@ func copyExistingContents(source, destination string) error {
 	}
 	return fs.CopyDir(destination, source)
 }
+
+// WithRemappedSnapshot creates a snapshot of the image with the ownership of
+// its files shifted by uid and gid, for containers running in a user
+// namespace. The shifted image snapshot is committed and shared by containers
+// with the same ID mapping. Unlike `containerd.WithRemappedSnapshot`, it
+// uses the rootfs of the platform selected by the image itself, and maps
+// file owners not fitting in the size of the user namespace to the overflow
+// id instead of ids of another range.
+func WithRemappedSnapshot(id string, i containerd.Image, uid, gid, size uint32) containerd.NewContainerOpts {
+	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+		if size == 0 {
+			return errors.New("user namespace size must not be 0")
+		}
+		// Hold a lease, so that the committed remapped snapshot is not
+		// garbage collected before the container snapshot referencing it
+		// is prepared.
+		ctx, done, err := client.WithLease(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to create lease")
+		}
+		defer done() // nolint: errcheck
+
+		diffIDs, err := i.RootFS(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to get image rootfs")
+		}
+		if c.Snapshotter == "" {
+			return errors.New("no snapshotter set for container")
+		}
+		var (
+			snapshotter = client.SnapshotService(c.Snapshotter)
+			parent      = identity.ChainID(diffIDs).String()
+			remappedID  = fmt.Sprintf("%s-%d-%d-%d", parent, uid, gid, size)
+		)
+		if _, err := snapshotter.Stat(ctx, remappedID); err != nil {
+			if !errdefs.IsNotFound(err) {
+				return errors.Wrapf(err, "failed to stat snapshot %q", remappedID)
+			}
+			if _, err := snapshotter.Stat(ctx, parent); err != nil {
+				if !errdefs.IsNotFound(err) {
+					return errors.Wrapf(err, "failed to stat snapshot %q", parent)
+				}
+				if err := i.Unpack(ctx, c.Snapshotter); err != nil {
+					return errors.Wrap(err, "error unpacking image")
+				}
+			}
+			// Use a key unique to the container, so that concurrent
+			// remapping of the same image doesn't conflict.
+			key := remappedID + "-remap-" + id
+			mounts, err := snapshotter.Prepare(ctx, key, parent)
+			if err != nil {
+				return errors.Wrapf(err, "failed to prepare snapshot %q", key)
+			}
+			if err := mount.WithTempMount(ctx, mounts, func(root string) error {
+				return filepath.Walk(root, shiftOwnership(uid, gid, size))
+			}); err != nil {
+				snapshotter.Remove(ctx, key) // nolint: errcheck
+				return errors.Wrap(err, "failed to remap snapshot")
+			}
+			if err := snapshotter.Commit(ctx, remappedID, key); err != nil {
+				snapshotter.Remove(ctx, key) // nolint: errcheck
+				if !errdefs.IsAlreadyExists(err) {
+					return errors.Wrapf(err, "failed to commit snapshot %q", remappedID)
+				}
+			}
+		}
+		if _, err := snapshotter.Prepare(ctx, id, remappedID); err != nil {
+			return errors.Wrapf(err, "failed to prepare snapshot %q", id)
+		}
+		c.SnapshotKey = id
+		c.Image = i.Name()
+		return nil
+	}
+}
+
+// overflowID is the id files owned by ids out of the user namespace are
+// mapped to, the same as the kernel default overflowuid and overflowgid.
+const overflowID = 65534
+
+// shiftID returns the host id of the id in a user namespace of the size
+// starting at base. Ids out of the user namespace are mapped to the overflow
+// id, or the last id if the overflow id is out of the user namespace either.
+func shiftID(id, base, size uint32) int {
+	if id >= size {
+		id = overflowID
+		if id >= size {
+			id = size - 1
+		}
+	}
+	return int(base) + int(id)
+}
+
+// shiftOwnership returns a walk function shifting the ownership of files by
+// uid and gid into a user namespace of the size. Setuid and setgid bits
+// cleared by chown are restored.
+func shiftOwnership(uid, gid, size uint32) filepath.WalkFunc {
+	return func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		stat, ok := info.Sys().(*syscall.Stat_t)
+		if !ok {
+			return errors.Errorf("failed to get ownership of %q", path)
+		}
+		// Use lchown, so that symlinks pointing to host files are not
+		// dereferenced.
+		if err := os.Lchown(path, shiftID(stat.Uid, uid, size), shiftID(stat.Gid, gid, size)); err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink == 0 && info.Mode()&(os.ModeSetuid|os.ModeSetgid) != 0 {
+			return os.Chmod(path, info.Mode())
+		}
+		return nil
+	}
+}
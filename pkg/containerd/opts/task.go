@@ -20,7 +20,9 @@ import (
 	"context"
 
 	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/api/types"
 	"github.com/containerd/containerd/linux/runctypes"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 // WithContainerdShimCgroup returns function that sets the containerd
@@ -34,5 +36,18 @@ func WithContainerdShimCgroup(path string) containerd.NewTaskOpts {
 	}
 }
 
+// WithCheckpoint returns function that restores the task from the
+// CRIU image in the content store.
+func WithCheckpoint(desc ocispec.Descriptor) containerd.NewTaskOpts {
+	return func(_ context.Context, _ *containerd.Client, r *containerd.TaskInfo) error {
+		r.Checkpoint = &types.Descriptor{
+			MediaType: desc.MediaType,
+			Digest:    desc.Digest,
+			Size_:     desc.Size,
+		}
+		return nil
+	}
+}
+
 //TODO: Since Options is an interface different WithXXX will be needed to set different
 // combinations of CreateOptions.
@@ -52,6 +52,13 @@ func (r dockerFetcher) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.R
 		},
 	))
 
+	// Local OCI image layouts are tried before remote registries.
+	if rc, err := fetchLayouts(ctx, r.layouts, desc); err == nil {
+		return rc, nil
+	} else if !errdefs.IsNotFound(err) {
+		return nil, err
+	}
+
 	urls, err := r.getV2URLPaths(ctx, desc)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,228 @@
+/*
+Copyright 2018 The Containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/log"
+	"github.com/containerd/containerd/reference"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// This file adds support for local OCI image layout directories as registry
+// mirrors. A mirror endpoint with the `file://` scheme is treated as a
+// read-only OCI image layout store: tags are resolved from index.json, and
+// blobs are served from the blobs directory.
+//
+// Images can be staged either in a layout directory per repository, e.g.
+// `<root>/library/busybox`, where manifests are matched by the
+// `org.opencontainers.image.ref.name` annotation, or all in the root layout
+// directory, where manifests are matched by the full image name in the
+// `io.containerd.image.name` annotation (the format written by image export).
+
+const (
+	// fileScheme is the url scheme of local OCI image layout mirrors.
+	fileScheme = "file"
+	// imageNameAnnotation is the annotation containing the full image name.
+	imageNameAnnotation = "io.containerd.image.name"
+)
+
+// ociLayout is a local read-only OCI image layout directory.
+type ociLayout struct {
+	// dir is the layout directory.
+	dir string
+	// matchTag indicates whether the manifests in the layout can be matched
+	// with the tag only.
+	matchTag bool
+}
+
+// getLayouts returns the candidate OCI image layouts of an image in the
+// local mirror root directory.
+func getLayouts(root, imagePath string) []ociLayout {
+	return []ociLayout{
+		{dir: filepath.Join(root, filepath.FromSlash(imagePath)), matchTag: true},
+		{dir: root},
+	}
+}
+
+// parseFileURL returns the local directory of a `file://` mirror endpoint.
+func parseFileURL(u *url.URL) (string, error) {
+	if u.Host != "" && u.Host != "localhost" {
+		return "", errors.Errorf("unsupported host %q in file url", u.Host)
+	}
+	if !filepath.IsAbs(u.Path) {
+		return "", errors.Errorf("file url path %q is not absolute", u.Path)
+	}
+	return filepath.Clean(u.Path), nil
+}
+
+// exists returns whether the directory is an OCI image layout.
+func (l ociLayout) exists() bool {
+	_, err := os.Stat(filepath.Join(l.dir, ocispec.ImageLayoutFile))
+	return err == nil
+}
+
+// index reads the index.json of the layout.
+func (l ociLayout) index() (*ocispec.Index, error) {
+	b, err := ioutil.ReadFile(filepath.Join(l.dir, "index.json"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read index.json")
+	}
+	var index ocispec.Index
+	if err := json.Unmarshal(b, &index); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal index.json")
+	}
+	return &index, nil
+}
+
+// blobPath returns the path of a blob in the layout.
+func (l ociLayout) blobPath(dgst digest.Digest) (string, error) {
+	if err := dgst.Validate(); err != nil {
+		return "", err
+	}
+	return filepath.Join(l.dir, "blobs", dgst.Algorithm().String(), dgst.Hex()), nil
+}
+
+// open opens a blob in the layout. errdefs.ErrNotFound is returned if the
+// blob doesn't exist.
+func (l ociLayout) open(dgst digest.Digest) (*os.File, error) {
+	p, err := l.blobPath(dgst)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Wrapf(errdefs.ErrNotFound, "blob %v not found in %q", dgst, l.dir)
+		}
+		return nil, errors.Wrapf(err, "failed to open blob %v in %q", dgst, l.dir)
+	}
+	return f, nil
+}
+
+// resolve resolves the image reference in the layout. errdefs.ErrNotFound
+// is returned if the image is not in the layout.
+func (l ociLayout) resolve(refspec reference.Spec) (ocispec.Descriptor, error) {
+	if !l.exists() {
+		return ocispec.Descriptor{}, errors.Wrapf(errdefs.ErrNotFound, "%q is not an oci image layout", l.dir)
+	}
+	index, err := l.index()
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	dgst := refspec.Digest()
+	for _, m := range index.Manifests {
+		if dgst != "" {
+			if m.Digest == dgst {
+				return m, nil
+			}
+			continue
+		}
+		if m.Annotations[imageNameAnnotation] == refspec.String() {
+			return m, nil
+		}
+		if l.matchTag && m.Annotations[ocispec.AnnotationRefName] == refspec.Object {
+			return m, nil
+		}
+	}
+	if dgst == "" {
+		return ocispec.Descriptor{}, errors.Wrapf(errdefs.ErrNotFound, "%v not found in %q", refspec, l.dir)
+	}
+	// The digest may reference a manifest which is not in the index, e.g.
+	// the manifest of a platform in a manifest list.
+	f, err := l.open(dgst)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return ocispec.Descriptor{}, errors.Wrapf(err, "failed to stat blob %v", dgst)
+	}
+	mediaType, err := detectMediaType(f)
+	if err != nil {
+		return ocispec.Descriptor{}, errors.Wrapf(err, "failed to detect media type of blob %v", dgst)
+	}
+	return ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    dgst,
+		Size:      fi.Size(),
+	}, nil
+}
+
+// detectMediaType detects the media type of a manifest or an index.
+func detectMediaType(r io.Reader) (string, error) {
+	var m struct {
+		MediaType string            `json:"mediaType"`
+		Manifests []json.RawMessage `json:"manifests"`
+		Layers    []json.RawMessage `json:"layers"`
+	}
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return "", err
+	}
+	switch {
+	case m.MediaType != "":
+		return m.MediaType, nil
+	case m.Manifests != nil:
+		return ocispec.MediaTypeImageIndex, nil
+	case m.Layers != nil:
+		return ocispec.MediaTypeImageManifest, nil
+	}
+	return "", errors.New("unknown manifest type")
+}
+
+// resolveLayouts resolves the image reference in local OCI image layouts.
+func resolveLayouts(ctx context.Context, layouts []ociLayout, refspec reference.Spec) (ocispec.Descriptor, error) {
+	for _, l := range layouts {
+		desc, err := l.resolve(refspec)
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				continue
+			}
+			return ocispec.Descriptor{}, err
+		}
+		log.G(ctx).WithField("layout", l.dir).WithField("desc.digest", desc.Digest).Debug("resolved from oci layout")
+		return desc, nil
+	}
+	return ocispec.Descriptor{}, errors.Wrapf(errdefs.ErrNotFound, "%v not found in oci layouts", refspec)
+}
+
+// fetchLayouts fetches the content from local OCI image layouts.
+func fetchLayouts(ctx context.Context, layouts []ociLayout, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	for _, l := range layouts {
+		f, err := l.open(desc.Digest)
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		log.G(ctx).WithField("layout", l.dir).Debug("fetch from oci layout")
+		return f, nil
+	}
+	return nil, errors.Wrapf(errdefs.ErrNotFound, "%v (%v) not found in oci layouts", desc.Digest, desc.MediaType)
+}
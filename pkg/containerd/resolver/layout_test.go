@@ -0,0 +1,133 @@
+/*
+Copyright 2018 The Containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/reference"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestLayout writes an OCI image layout with a manifest blob and the
+// index annotations.
+func writeTestLayout(t *testing.T, dir string, manifest []byte, annotations map[string]string) ocispec.Descriptor {
+	dgst := digest.FromBytes(manifest)
+	blobDir := filepath.Join(dir, "blobs", dgst.Algorithm().String())
+	require.NoError(t, os.MkdirAll(blobDir, 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(blobDir, dgst.Hex()), manifest, 0644))
+	desc := ocispec.Descriptor{
+		MediaType:   ocispec.MediaTypeImageManifest,
+		Digest:      dgst,
+		Size:        int64(len(manifest)),
+		Annotations: annotations,
+	}
+	index, err := json.Marshal(ocispec.Index{Manifests: []ocispec.Descriptor{desc}})
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "index.json"), index, 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, ocispec.ImageLayoutFile),
+		[]byte(`{"imageLayoutVersion":"1.0.0"}`), 0644))
+	return desc
+}
+
+func TestResolveLayouts(t *testing.T) {
+	root, err := ioutil.TempDir("", "test-layout")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	rootManifest := []byte(`{"schemaVersion":2,"layers":[]}`)
+	rootDesc := writeTestLayout(t, root, rootManifest, map[string]string{
+		imageNameAnnotation: "docker.io/library/busybox:latest",
+	})
+	repoManifest := []byte(`{"schemaVersion":2,"layers":[{}]}`)
+	repoDesc := writeTestLayout(t, filepath.Join(root, "library", "alpine"), repoManifest, map[string]string{
+		ocispec.AnnotationRefName: "3.8",
+	})
+	unindexed := []byte(`{"schemaVersion":2,"manifests":[]}`)
+	unindexedDgst := digest.FromBytes(unindexed)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(root, "blobs", "sha256", unindexedDgst.Hex()), unindexed, 0644))
+
+	for desc, test := range map[string]struct {
+		ref       string
+		imagePath string
+		expected  ocispec.Descriptor
+		notFound  bool
+	}{
+		"should resolve image by full name in root layout": {
+			ref:       "docker.io/library/busybox:latest",
+			imagePath: "library/busybox",
+			expected:  rootDesc,
+		},
+		"should resolve image by tag in repository layout": {
+			ref:       "docker.io/library/alpine:3.8",
+			imagePath: "library/alpine",
+			expected:  repoDesc,
+		},
+		"should not resolve image by tag in root layout": {
+			ref:       "docker.io/library/busybox:3.8",
+			imagePath: "library/busybox",
+			notFound:  true,
+		},
+		"should resolve image by indexed digest": {
+			ref:       "docker.io/library/busybox@" + rootDesc.Digest.String(),
+			imagePath: "library/busybox",
+			expected:  rootDesc,
+		},
+		"should resolve image by unindexed digest": {
+			ref:       "docker.io/library/busybox@" + unindexedDgst.String(),
+			imagePath: "library/busybox",
+			expected: ocispec.Descriptor{
+				MediaType: ocispec.MediaTypeImageIndex,
+				Digest:    unindexedDgst,
+				Size:      int64(len(unindexed)),
+			},
+		},
+		"should return not found for non-exist image": {
+			ref:       "docker.io/library/nginx:latest",
+			imagePath: "library/nginx",
+			notFound:  true,
+		},
+	} {
+		t.Logf("TestCase %q", desc)
+		refspec, err := reference.Parse(test.ref)
+		require.NoError(t, err)
+		layouts := getLayouts(root, test.imagePath)
+		got, err := resolveLayouts(context.Background(), layouts, refspec)
+		if test.notFound {
+			assert.True(t, errdefs.IsNotFound(err))
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, got)
+
+		rc, err := fetchLayouts(context.Background(), layouts, got)
+		require.NoError(t, err)
+		b, err := ioutil.ReadAll(rc)
+		rc.Close()
+		require.NoError(t, err)
+		assert.Equal(t, got.Digest, digest.FromBytes(b))
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/containerd/containerd/errdefs"
 	"github.com/containerd/containerd/images"
 	"github.com/containerd/containerd/log"
 	"github.com/containerd/containerd/reference"
@@ -51,7 +52,8 @@ import (
 // function to calculate the base urls for the image location.  urls() is
 // added to fetch the mirror urls associated with the namespace of the image
 // ResolverOptions are changed for client to set the namespace and mirror urls
-// for to pull the image.
+// for to pull the image. Mirror urls with the `file://` scheme are served
+// from local OCI image layout directories, see layout.go.
 
 var (
 	// ErrNoToken is returned if a request is successful but the body does not
@@ -125,6 +127,13 @@ func (r *containerdResolver) Resolve(ctx context.Context, ref string) (string, o
 		return "", ocispec.Descriptor{}, err
 	}
 
+	// Local OCI image layouts are tried before remote registries.
+	if desc, err := resolveLayouts(ctx, base.layouts, refspec); err == nil {
+		return ref, desc, nil
+	} else if !errdefs.IsNotFound(err) {
+		return "", ocispec.Descriptor{}, err
+	}
+
 	fetcher := dockerFetcher{
 		dockerBase: base,
 	}
@@ -250,6 +259,7 @@ func (r *containerdResolver) Pusher(ctx context.Context, ref string) (remotes.Pu
 type dockerBase struct {
 	refspec reference.Spec
 	base    []url.URL
+	layouts []ociLayout
 	token   string
 
 	client   *http.Client
@@ -262,6 +272,7 @@ func (r *containerdResolver) base(refspec reference.Spec) (*dockerBase, error) {
 	var (
 		err              error
 		base             []url.URL
+		layouts          []ociLayout
 		username, secret string
 	)
 
@@ -269,11 +280,12 @@ func (r *containerdResolver) base(refspec reference.Spec) (*dockerBase, error) {
 	prefix := strings.TrimPrefix(refspec.Locator, host+"/")
 
 	if urls, ok := r.registry[host]; ok {
-		urls, err := r.getV2Urls(urls, prefix)
+		urls, localLayouts, err := r.getV2Urls(urls, prefix)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to fetch v2 urls")
 		}
 		base = append(base, urls...)
+		layouts = append(layouts, localLayouts...)
 	} else if host == "docker.io" {
 		base = append(base, []url.URL{{Host: "registry-1.docker.io", Scheme: "https", Path: path.Join("/v2", prefix)}}...)
 	} else {
@@ -284,7 +296,7 @@ func (r *containerdResolver) base(refspec reference.Spec) (*dockerBase, error) {
 		base = append(base, []url.URL{{Host: host, Scheme: scheme, Path: path.Join("/v2", prefix)}}...)
 	}
 
-	if r.credentials != nil {
+	if r.credentials != nil && len(base) > 0 {
 		username, secret, err = r.credentials(base[0].Host)
 		if err != nil {
 			return nil, err
@@ -294,6 +306,7 @@ func (r *containerdResolver) base(refspec reference.Spec) (*dockerBase, error) {
 	return &dockerBase{
 		refspec:  refspec,
 		base:     base,
+		layouts:  layouts,
 		client:   r.client,
 		username: username,
 		secret:   secret,
@@ -586,15 +599,26 @@ func (r *dockerBase) getToken(ctx context.Context, to tokenOptions) (string, err
 	return tr.Token, nil
 }
 
-func (r *containerdResolver) getV2Urls(urls []string, imagePath string) ([]url.URL, error) {
+// getV2Urls returns the v2 urls of remote mirrors and the candidate OCI
+// image layouts of local `file://` mirrors.
+func (r *containerdResolver) getV2Urls(urls []string, imagePath string) ([]url.URL, []ociLayout, error) {
 	v2Urls := []url.URL{}
+	var layouts []ociLayout
 	for _, u := range urls {
 		v2Url, err := url.Parse(u)
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to parse url during getv2 urls: %+v", u)
+			return nil, nil, errors.Wrapf(err, "failed to parse url during getv2 urls: %+v", u)
+		}
+		if v2Url.Scheme == fileScheme {
+			root, err := parseFileURL(v2Url)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "invalid local mirror %+v", u)
+			}
+			layouts = append(layouts, getLayouts(root, imagePath)...)
+			continue
 		}
 		v2Url.Path = path.Join("/v2", imagePath)
 		v2Urls = append(v2Urls, *v2Url)
 	}
-	return v2Urls, nil
+	return v2Urls, layouts, nil
 }
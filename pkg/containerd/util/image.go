@@ -0,0 +1,284 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/platforms"
+	"github.com/containerd/containerd/rootfs"
+	"github.com/containerd/containerd/snapshots"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/identity"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// ResolveManifest returns the manifest descriptor of the first matching
+// platform if the descriptor is an index, or the descriptor itself if it
+// is a manifest. Platforms are in the order of preference.
+func ResolveManifest(ctx context.Context, provider content.Provider, desc ocispec.Descriptor,
+	platformList []ocispec.Platform) (ocispec.Descriptor, error) {
+	switch desc.MediaType {
+	case ocispec.MediaTypeImageManifest, images.MediaTypeDockerSchema2Manifest:
+		return desc, nil
+	case ocispec.MediaTypeImageIndex, images.MediaTypeDockerSchema2ManifestList:
+	default:
+		return ocispec.Descriptor{}, errors.Errorf("unsupported media type %q", desc.MediaType)
+	}
+	b, err := content.ReadBlob(ctx, provider, desc.Digest)
+	if err != nil {
+		return ocispec.Descriptor{}, errors.Wrapf(err, "read index %q", desc.Digest)
+	}
+	var idx ocispec.Index
+	if err := json.Unmarshal(b, &idx); err != nil {
+		return ocispec.Descriptor{}, errors.Wrapf(err, "unmarshal index %q", desc.Digest)
+	}
+	for _, p := range platformList {
+		matcher := platforms.NewMatcher(p)
+		for _, m := range idx.Manifests {
+			if m.Platform != nil && !matcher.Match(*m.Platform) {
+				continue
+			}
+			return ResolveManifest(ctx, provider, m, platformList)
+		}
+	}
+	return ocispec.Descriptor{}, errors.Wrapf(errdefs.ErrNotFound, "no manifest in index %q matches platforms %v",
+		desc.Digest, formatPlatforms(platformList))
+}
+
+// PlatformImage is a containerd image bound to the manifest of a selected
+// platform. Methods of containerd.Image in containerd client always use the
+// default platform, PlatformImage uses the selected manifest instead.
+type PlatformImage struct {
+	client *containerd.Client
+	image  containerd.Image
+	// manifest is the descriptor of the selected manifest.
+	manifest ocispec.Descriptor
+	// platform is the selected platform.
+	platform ocispec.Platform
+}
+
+var _ containerd.Image = &PlatformImage{}
+
+// NewPlatformImage selects the manifest of the first matching platform for
+// the image. Platforms are in the order of preference. An image with a
+// single manifest is only accepted if its config matches one of the platforms.
+// Empty os and architecture in the config, e.g. of images saved by old docker,
+// are considered the default platform.
+func NewPlatformImage(ctx context.Context, client *containerd.Client, image containerd.Image,
+	platformList []ocispec.Platform) (*PlatformImage, error) {
+	cs := client.ContentStore()
+	manifest, err := ResolveManifest(ctx, cs, image.Target(), platformList)
+	if err != nil {
+		return nil, err
+	}
+	i := &PlatformImage{
+		client:   client,
+		image:    image,
+		manifest: manifest,
+	}
+	if manifest.Platform != nil {
+		i.platform = platforms.Normalize(*manifest.Platform)
+		return i, nil
+	}
+	// The platform is not in the descriptor, get it from the image config.
+	desc, err := i.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+	b, err := content.ReadBlob(ctx, cs, desc.Digest)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read image config %q", desc.Digest)
+	}
+	var config ocispec.Image
+	if err := json.Unmarshal(b, &config); err != nil {
+		return nil, errors.Wrapf(err, "unmarshal image config %q", desc.Digest)
+	}
+	i.platform = configPlatform(config)
+	for _, p := range platformList {
+		if platforms.NewMatcher(p).Match(i.platform) {
+			return i, nil
+		}
+	}
+	return nil, errors.Wrapf(errdefs.ErrNotFound, "image platform %q doesn't match platforms %v",
+		platforms.Format(i.platform), formatPlatforms(platformList))
+}
+
+// configPlatform returns the platform of the image config, empty os and
+// architecture are filled with those of the default platform.
+func configPlatform(config ocispec.Image) ocispec.Platform {
+	p := ocispec.Platform{OS: config.OS, Architecture: config.Architecture}
+	def := platforms.DefaultSpec()
+	if p.OS == "" {
+		p.OS = def.OS
+	}
+	if p.Architecture == "" {
+		p.Architecture = def.Architecture
+		p.Variant = def.Variant
+	}
+	return platforms.Normalize(p)
+}
+
+// Platform returns the selected platform.
+func (i *PlatformImage) Platform() ocispec.Platform {
+	return i.platform
+}
+
+// Manifest returns the descriptor of the selected manifest.
+func (i *PlatformImage) Manifest() ocispec.Descriptor {
+	return i.manifest
+}
+
+// Name returns the name of the image.
+func (i *PlatformImage) Name() string {
+	return i.image.Name()
+}
+
+// Target returns the target descriptor of the image, which may be an index.
+func (i *PlatformImage) Target() ocispec.Descriptor {
+	return i.image.Target()
+}
+
+// ContentStore returns the content store of the image.
+func (i *PlatformImage) ContentStore() content.Store {
+	return i.client.ContentStore()
+}
+
+// platformManifest returns an image with the selected manifest as target.
+// Empty platform should be used with it, because the manifest is selected.
+func (i *PlatformImage) platformManifest() *images.Image {
+	return &images.Image{
+		Name:   i.image.Name(),
+		Target: i.manifest,
+	}
+}
+
+// RootFS returns the diff ids of the selected manifest.
+func (i *PlatformImage) RootFS(ctx context.Context) ([]digest.Digest, error) {
+	return i.platformManifest().RootFS(ctx, i.client.ContentStore(), "")
+}
+
+// Size returns the size of the selected manifest and its blobs, and the
+// index if there is one.
+func (i *PlatformImage) Size(ctx context.Context) (int64, error) {
+	size, err := i.platformManifest().Size(ctx, i.client.ContentStore(), "")
+	if err != nil {
+		return 0, err
+	}
+	if i.manifest.Digest != i.Target().Digest {
+		size += i.Target().Size
+	}
+	return size, nil
+}
+
+// Config returns the config descriptor of the selected manifest.
+func (i *PlatformImage) Config(ctx context.Context) (ocispec.Descriptor, error) {
+	return i.platformManifest().Config(ctx, i.client.ContentStore(), "")
+}
+
+// IsUnpacked returns whether the selected manifest is unpacked.
+func (i *PlatformImage) IsUnpacked(ctx context.Context, snapshotterName string) (bool, error) {
+	diffIDs, err := i.RootFS(ctx)
+	if err != nil {
+		return false, err
+	}
+	if _, err := i.client.SnapshotService(snapshotterName).Stat(ctx, identity.ChainID(diffIDs).String()); err != nil {
+		if errdefs.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Unpack unpacks layers of the selected manifest into the snapshotter. This
+// is the same with Unpack of containerd client except the manifest used.
+func (i *PlatformImage) Unpack(ctx context.Context, snapshotterName string) error {
+	ctx, done, err := i.client.WithLease(ctx)
+	if err != nil {
+		return err
+	}
+	defer done() // nolint: errcheck
+
+	cs := i.client.ContentStore()
+	manifest, err := images.Manifest(ctx, cs, i.manifest, "")
+	if err != nil {
+		return err
+	}
+	diffIDs, err := i.RootFS(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to resolve rootfs")
+	}
+	if len(diffIDs) != len(manifest.Layers) {
+		return errors.New("mismatched image rootfs and manifest layers")
+	}
+	var (
+		sn       = i.client.SnapshotService(snapshotterName)
+		a        = i.client.DiffService()
+		chain    []digest.Digest
+		unpacked bool
+	)
+	for n, diffID := range diffIDs {
+		layer := rootfs.Layer{
+			Diff: ocispec.Descriptor{
+				MediaType: ocispec.MediaTypeImageLayer,
+				Digest:    diffID,
+			},
+			Blob: manifest.Layers[n],
+		}
+		labels := map[string]string{
+			"containerd.io/uncompressed": diffID.String(),
+		}
+		unpacked, err = rootfs.ApplyLayer(ctx, layer, chain, sn, a, snapshots.WithLabels(labels))
+		if err != nil {
+			return err
+		}
+		chain = append(chain, diffID)
+	}
+	if !unpacked {
+		return nil
+	}
+	// Reference the snapshot from the config, so that it is not garbage
+	// collected.
+	label := fmt.Sprintf("containerd.io/gc.ref.snapshot.%s", snapshotterName)
+	info := content.Info{
+		Digest: manifest.Config.Digest,
+		Labels: map[string]string{
+			label: identity.ChainID(chain).String(),
+		},
+	}
+	if _, err := cs.Update(ctx, info, "labels."+label); err != nil {
+		return err
+	}
+	return nil
+}
+
+// formatPlatforms formats platforms for logging.
+func formatPlatforms(platformList []ocispec.Platform) []string {
+	var formatted []string
+	for _, p := range platformList {
+		formatted = append(formatted, platforms.Format(p))
+	}
+	return formatted
+}
@@ -39,6 +39,7 @@ type OS interface {
 	ResolveSymbolicLink(name string) (string, error)
 	CopyFile(src, dest string, perm os.FileMode) error
 	WriteFile(filename string, data []byte, perm os.FileMode) error
+	Lchown(name string, uid, gid int) error
 	Mount(source string, target string, fstype string, flags uintptr, data string) error
 	Unmount(target string, flags int) error
 	LookupMount(path string) (containerdmount.Info, error)
@@ -102,6 +103,11 @@ func (RealOS) WriteFile(filename string, data []byte, perm os.FileMode) error {
 	return ioutil.WriteFile(filename, data, perm)
 }
 
+// Lchown will call os.Lchown to change the ownership of the file.
+func (RealOS) Lchown(name string, uid, gid int) error {
+	return os.Lchown(name, uid, gid)
+}
+
 // Mount will call unix.Mount to mount the file.
 func (RealOS) Mount(source string, target string, fstype string, flags uintptr, data string) error {
 	return unix.Mount(source, target, fstype, flags, data)
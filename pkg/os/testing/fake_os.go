@@ -47,6 +47,7 @@ type FakeOS struct {
 	ResolveSymbolicLinkFn func(string) (string, error)
 	CopyFileFn            func(string, string, os.FileMode) error
 	WriteFileFn           func(string, []byte, os.FileMode) error
+	LchownFn              func(string, int, int) error
 	MountFn               func(source string, target string, fstype string, flags uintptr, data string) error
 	UnmountFn             func(target string, flags int) error
 	LookupMountFn         func(path string) (containerdmount.Info, error)
@@ -202,6 +203,19 @@ func (f *FakeOS) WriteFile(filename string, data []byte, perm os.FileMode) error
 	return nil
 }
 
+// Lchown is a fake call that invokes LchownFn or just return nil.
+func (f *FakeOS) Lchown(name string, uid, gid int) error {
+	f.appendCalls("Lchown", name, uid, gid)
+	if err := f.getError("Lchown"); err != nil {
+		return err
+	}
+
+	if f.LchownFn != nil {
+		return f.LchownFn(name, uid, gid)
+	}
+	return nil
+}
+
 // Mount is a fake call that invokes MountFn or just return nil.
 func (f *FakeOS) Mount(source string, target string, fstype string, flags uintptr, data string) error {
 	f.appendCalls("Mount", source, target, fstype, flags, data)
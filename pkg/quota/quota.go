@@ -0,0 +1,346 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// ErrNotSupported is returned when project quota is not supported or not
+// enabled on the filesystem.
+var ErrNotSupported = errors.New("project quota is not supported")
+
+const (
+	// backingFsBlockDevName is the name of the block device node created
+	// for quotactl.
+	backingFsBlockDevName = "backingFsBlockDev"
+
+	// Following are from linux/quota.h and linux/fs.h.
+	prjQuota       = 2
+	qGetQuota      = 0x800007
+	qSetQuota      = 0x800008
+	qifBLimits     = 1
+	qifDqblkSize   = 1024
+	fsIocGetXattr  = 0x801c581f
+	fsIocSetXattr  = 0x401c5820
+	fsXflagInherit = 0x200
+)
+
+// ifDqblk is struct if_dqblk in linux/quota.h.
+type ifDqblk struct {
+	bHardLimit uint64
+	bSoftLimit uint64
+	curSpace   uint64
+	iHardLimit uint64
+	iSoftLimit uint64
+	curInodes  uint64
+	bTime      uint64
+	iTime      uint64
+	valid      uint32
+}
+
+// fsxattr is struct fsxattr in linux/fs.h.
+type fsxattr struct {
+	xflags     uint32
+	extsize    uint32
+	nextents   uint32
+	projid     uint32
+	cowextsize uint32
+	pad        [8]byte
+}
+
+// Usage is the disk usage of a project.
+type Usage struct {
+	// Size is the bytes used by the project.
+	Size int64
+	// Inodes is the number of inodes used by the project.
+	Inodes int64
+}
+
+// Control manages project quotas of directories on a filesystem. Each
+// directory is assigned an unique project id, so that its disk usage could
+// be got from quota accounting without walking the directory.
+type Control struct {
+	// backingFsBlockDev is the block device node of the filesystem.
+	backingFsBlockDev string
+	// baseProjectID is the project id of the base directory, all assigned
+	// project ids are larger than it.
+	baseProjectID uint32
+
+	// Following are the quota and project id operations on the filesystem,
+	// they are replaced in unit tests.
+	getQuota     func(backingFsBlockDev string, id uint32) (*ifDqblk, error)
+	setQuota     func(backingFsBlockDev string, id uint32, bytes uint64) error
+	getProjectID func(path string) (uint32, error)
+	setProjectID func(path string, id uint32) error
+
+	lock sync.Mutex
+	// nextProjectID is the next project id to assign.
+	nextProjectID uint32
+	// projectIDs is the project ids assigned to directories.
+	projectIDs map[string]uint32
+}
+
+// NewControl creates a quota control for the filesystem of basePath. The
+// block device node used for quotactl is created in stateDir. ErrNotSupported
+// is returned if project quota is not enabled on the filesystem.
+func NewControl(basePath, stateDir string) (*Control, error) {
+	baseProjectID, err := getProjectID(basePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get project id of %q", basePath)
+	}
+	backingFsBlockDev, err := makeBackingFsBlockDev(basePath, stateDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to make backing filesystem block device")
+	}
+	// Check whether project quota is enabled by getting the quota of the
+	// base project.
+	if _, err := getQuota(backingFsBlockDev, baseProjectID); err != nil {
+		return nil, errors.Wrapf(ErrNotSupported, "failed to get quota: %v", err)
+	}
+	return &Control{
+		backingFsBlockDev: backingFsBlockDev,
+		baseProjectID:     baseProjectID,
+		getQuota:          getQuota,
+		setQuota:          setQuota,
+		getProjectID:      getProjectID,
+		setProjectID:      setProjectID,
+		nextProjectID:     baseProjectID + 1,
+		projectIDs:        make(map[string]uint32),
+	}, nil
+}
+
+// AssignProjectID assigns an unique project id to the directory if it doesn't
+// have one yet, and returns the project id. Existing files in the directory
+// are assigned the project id as well, new files inherit it.
+func (c *Control) AssignProjectID(dir string) (uint32, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if id, ok := c.projectIDs[dir]; ok {
+		return id, nil
+	}
+	id, err := c.getProjectID(dir)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get project id")
+	}
+	// The directory may be assigned before restart.
+	if id > c.baseProjectID {
+		c.projectIDs[dir] = id
+		if id >= c.nextProjectID {
+			c.nextProjectID = id + 1
+		}
+		return id, nil
+	}
+	id, err = c.nextFreeProjectID()
+	if err != nil {
+		return 0, err
+	}
+	if err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		// Only regular files and directories could be opened safely.
+		if !info.Mode().IsRegular() && !info.IsDir() {
+			return nil
+		}
+		return c.setProjectID(path, id)
+	}); err != nil {
+		return 0, errors.Wrapf(err, "failed to set project id %d", id)
+	}
+	c.projectIDs[dir] = id
+	c.nextProjectID = id + 1
+	return id, nil
+}
+
+// nextFreeProjectID returns the next project id which is neither assigned
+// nor used on the filesystem, e.g. by directories assigned before restart
+// which are not seen yet. Stale limit of the project id is cleared.
+func (c *Control) nextFreeProjectID() (uint32, error) {
+	used := make(map[uint32]bool)
+	for _, id := range c.projectIDs {
+		used[id] = true
+	}
+	for id := c.nextProjectID; id > c.baseProjectID; id++ {
+		if used[id] {
+			continue
+		}
+		d, err := c.getQuota(c.backingFsBlockDev, id)
+		if err != nil {
+			// XFS returns ENOENT or ESRCH if there is no quota record of
+			// the project id, which means it is not used.
+			if err == unix.ENOENT || err == unix.ESRCH {
+				return id, nil
+			}
+			return 0, errors.Wrapf(err, "failed to get quota of project %d", id)
+		}
+		if d.curInodes > 0 {
+			continue
+		}
+		if d.bHardLimit != 0 {
+			if err := c.setQuota(c.backingFsBlockDev, id, 0); err != nil {
+				return 0, errors.Wrapf(err, "failed to clear quota of project %d", id)
+			}
+		}
+		return id, nil
+	}
+	return 0, errors.New("no free project id")
+}
+
+// SetLimit sets the hard limit of disk usage in bytes for the directory.
+// The directory must have been assigned a project id. 0 means no limit.
+func (c *Control) SetLimit(dir string, bytes uint64) error {
+	c.lock.Lock()
+	id, ok := c.projectIDs[dir]
+	c.lock.Unlock()
+	if !ok {
+		return errors.Errorf("no project id assigned to %q", dir)
+	}
+	if err := c.setQuota(c.backingFsBlockDev, id, bytes); err != nil {
+		return errors.Wrapf(err, "failed to set quota of project %d", id)
+	}
+	return nil
+}
+
+// Release forgets the project id assigned to the directory. It should be
+// called after the directory is removed.
+func (c *Control) Release(dir string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.projectIDs, dir)
+}
+
+// GetUsage returns the disk usage of the directory from quota accounting.
+// The directory must have been assigned a project id.
+func (c *Control) GetUsage(dir string) (Usage, error) {
+	c.lock.Lock()
+	id, ok := c.projectIDs[dir]
+	c.lock.Unlock()
+	if !ok {
+		return Usage{}, errors.Errorf("no project id assigned to %q", dir)
+	}
+	d, err := c.getQuota(c.backingFsBlockDev, id)
+	if err != nil {
+		return Usage{}, errors.Wrapf(err, "failed to get quota of project %d", id)
+	}
+	return Usage{
+		Size:   int64(d.curSpace),
+		Inodes: int64(d.curInodes),
+	}, nil
+}
+
+// getQuota gets quota of a project.
+func getQuota(backingFsBlockDev string, id uint32) (*ifDqblk, error) {
+	var d ifDqblk
+	if err := quotactl(qGetQuota, backingFsBlockDev, id, unsafe.Pointer(&d)); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// setQuota sets the block hard limit of a project, the soft limit is cleared.
+// The limit is rounded up to quota block size.
+func setQuota(backingFsBlockDev string, id uint32, bytes uint64) error {
+	d := ifDqblk{
+		bHardLimit: (bytes + qifDqblkSize - 1) / qifDqblkSize,
+		valid:      qifBLimits,
+	}
+	return quotactl(qSetQuota, backingFsBlockDev, id, unsafe.Pointer(&d))
+}
+
+// quotactl calls quotactl syscall with project quota type.
+func quotactl(cmd int, special string, id uint32, addr unsafe.Pointer) error {
+	s, err := unix.BytePtrFromString(special)
+	if err != nil {
+		return err
+	}
+	if _, _, errno := unix.Syscall6(unix.SYS_QUOTACTL,
+		uintptr(cmd<<8|prjQuota), uintptr(unsafe.Pointer(s)), uintptr(id),
+		uintptr(addr), 0, 0); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// getProjectID gets the project id of a file.
+func getProjectID(path string) (uint32, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	var x fsxattr
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, f.Fd(), fsIocGetXattr,
+		uintptr(unsafe.Pointer(&x))); errno != 0 {
+		return 0, errno
+	}
+	return x.projid, nil
+}
+
+// setProjectID sets the project id of a file. If the file is a directory,
+// new files created in it inherit the project id.
+func setProjectID(path string, id uint32) error {
+	f, err := os.OpenFile(path, os.O_RDONLY|unix.O_NOFOLLOW, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	var x fsxattr
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, f.Fd(), fsIocGetXattr,
+		uintptr(unsafe.Pointer(&x))); errno != 0 {
+		return errno
+	}
+	x.projid = id
+	if info.IsDir() {
+		x.xflags |= fsXflagInherit
+	}
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, f.Fd(), fsIocSetXattr,
+		uintptr(unsafe.Pointer(&x))); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// makeBackingFsBlockDev creates a block device node for the filesystem
+// of basePath, which is used by quotactl.
+func makeBackingFsBlockDev(basePath, stateDir string) (string, error) {
+	var stat unix.Stat_t
+	if err := unix.Stat(basePath, &stat); err != nil {
+		return "", errors.Wrapf(err, "failed to stat %q", basePath)
+	}
+	if err := os.MkdirAll(stateDir, 0711); err != nil {
+		return "", errors.Wrapf(err, "failed to create %q", stateDir)
+	}
+	dev := filepath.Join(stateDir, backingFsBlockDevName)
+	// Recreate the device node, the filesystem may be changed.
+	if err := unix.Unlink(dev); err != nil && !os.IsNotExist(err) {
+		return "", errors.Wrapf(err, "failed to remove %q", dev)
+	}
+	if err := unix.Mknod(dev, unix.S_IFBLK|0600, int(stat.Dev)); err != nil {
+		return "", errors.Wrapf(err, "failed to mknod %q", dev)
+	}
+	return dev, nil
+}
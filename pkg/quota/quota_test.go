@@ -0,0 +1,213 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containerd/continuity/fs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+// The test directory must be on xfs or ext4 mounted with project quota
+// enabled, e.g. `mount -o prjquota`.
+var testDir = flag.String("quota-test-dir", "", "The directory on a filesystem with project quota enabled.")
+
+// setupBenchmark creates a directory with files for benchmark, and returns
+// a quota control with the directory assigned a project id.
+func setupBenchmark(b *testing.B) (*Control, string, func()) {
+	if *testDir == "" {
+		b.Skip("Quota test directory is not specified")
+	}
+	root, err := ioutil.TempDir(*testDir, "quota-benchmark")
+	require.NoError(b, err)
+	cleanup := func() { os.RemoveAll(root) }
+	c, err := NewControl(root, filepath.Join(root, "state"))
+	if err != nil {
+		cleanup()
+		b.Skipf("Project quota is not supported: %v", err)
+	}
+	dir := filepath.Join(root, "upper")
+	require.NoError(b, os.MkdirAll(dir, 0755))
+	_, err = c.AssignProjectID(dir)
+	require.NoError(b, err)
+	// Files are created after project id is assigned, so that they
+	// inherit the project id.
+	for i := 0; i < 100; i++ {
+		sub := filepath.Join(dir, fmt.Sprintf("dir-%d", i))
+		require.NoError(b, os.MkdirAll(sub, 0755))
+		for j := 0; j < 100; j++ {
+			require.NoError(b, ioutil.WriteFile(filepath.Join(sub, fmt.Sprintf("file-%d", j)), []byte("data"), 0644))
+		}
+	}
+	return c, dir, cleanup
+}
+
+// BenchmarkUsageWalk benchmarks getting usage by walking the directory,
+// which is what the overlayfs snapshotter does.
+func BenchmarkUsageWalk(b *testing.B) {
+	_, dir, cleanup := setupBenchmark(b)
+	defer cleanup()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := fs.DiskUsage(dir)
+		require.NoError(b, err)
+	}
+}
+
+// BenchmarkUsageQuota benchmarks getting usage from project quota accounting.
+func BenchmarkUsageQuota(b *testing.B) {
+	c, dir, cleanup := setupBenchmark(b)
+	defer cleanup()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := c.GetUsage(dir)
+		require.NoError(b, err)
+	}
+}
+
+// fakeFilesystem fakes project ids and quotas of a filesystem.
+type fakeFilesystem struct {
+	projectIDs map[string]uint32
+	quotas     map[uint32]*ifDqblk
+}
+
+// newTestControl creates a quota control with filesystem operations faked.
+// Project ids without quota record return ENOENT like XFS does.
+func newTestControl(baseProjectID uint32) (*Control, *fakeFilesystem) {
+	f := &fakeFilesystem{
+		projectIDs: make(map[string]uint32),
+		quotas:     make(map[uint32]*ifDqblk),
+	}
+	c := &Control{
+		backingFsBlockDev: "/test/backingFsBlockDev",
+		baseProjectID:     baseProjectID,
+		getQuota: func(_ string, id uint32) (*ifDqblk, error) {
+			d, ok := f.quotas[id]
+			if !ok {
+				return nil, unix.ENOENT
+			}
+			return d, nil
+		},
+		setQuota: func(_ string, id uint32, bytes uint64) error {
+			d, ok := f.quotas[id]
+			if !ok {
+				d = &ifDqblk{}
+				f.quotas[id] = d
+			}
+			d.bHardLimit = (bytes + qifDqblkSize - 1) / qifDqblkSize
+			return nil
+		},
+		getProjectID: func(path string) (uint32, error) {
+			return f.projectIDs[path], nil
+		},
+		setProjectID: func(path string, id uint32) error {
+			f.projectIDs[path] = id
+			return nil
+		},
+		nextProjectID: baseProjectID + 1,
+		projectIDs:    make(map[string]uint32),
+	}
+	return c, f
+}
+
+func TestAssignProjectID(t *testing.T) {
+	const baseProjectID = 10
+	root, err := ioutil.TempDir("", "quota-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+	newDir := func(name string) string {
+		dir := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0755))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "sub", "file"), []byte("data"), 0644))
+		require.NoError(t, os.Symlink("/etc/passwd", filepath.Join(dir, "link")))
+		return dir
+	}
+	c, f := newTestControl(baseProjectID)
+
+	t.Logf("should assign project id without quota record to new directory")
+	dir1 := newDir("dir-1")
+	id, err := c.AssignProjectID(dir1)
+	require.NoError(t, err)
+	assert.EqualValues(t, baseProjectID+1, id)
+	assert.Equal(t, map[string]uint32{
+		dir1:                               id,
+		filepath.Join(dir1, "sub"):         id,
+		filepath.Join(dir1, "sub", "file"): id,
+	}, f.projectIDs, "symlinks should not be assigned")
+
+	t.Logf("should return the same project id for assigned directory")
+	id, err = c.AssignProjectID(dir1)
+	require.NoError(t, err)
+	assert.EqualValues(t, baseProjectID+1, id)
+
+	t.Logf("should reuse project id assigned before restart")
+	dir2 := newDir("dir-2")
+	f.projectIDs[dir2] = baseProjectID + 5
+	id, err = c.AssignProjectID(dir2)
+	require.NoError(t, err)
+	assert.EqualValues(t, baseProjectID+5, id)
+
+	t.Logf("should skip project id used on the filesystem and clear stale limit")
+	f.quotas[baseProjectID+6] = &ifDqblk{curInodes: 1}
+	f.quotas[baseProjectID+7] = &ifDqblk{bHardLimit: 100}
+	dir3 := newDir("dir-3")
+	id, err = c.AssignProjectID(dir3)
+	require.NoError(t, err)
+	assert.EqualValues(t, baseProjectID+7, id)
+	assert.EqualValues(t, 0, f.quotas[baseProjectID+7].bHardLimit)
+
+	t.Logf("should set limit and get usage of assigned directory")
+	require.NoError(t, c.SetLimit(dir3, 1500))
+	assert.EqualValues(t, 2, f.quotas[baseProjectID+7].bHardLimit)
+	f.quotas[baseProjectID+7].curSpace = 4096
+	f.quotas[baseProjectID+7].curInodes = 3
+	usage, err := c.GetUsage(dir3)
+	require.NoError(t, err)
+	assert.Equal(t, Usage{Size: 4096, Inodes: 3}, usage)
+
+	t.Logf("should forget released directory")
+	c.Release(dir3)
+	assert.Error(t, c.SetLimit(dir3, 1500))
+	_, err = c.GetUsage(dir3)
+	assert.Error(t, err)
+
+	t.Logf("should not assign project id of released directory before it is freed")
+	dir4 := newDir("dir-4")
+	id, err = c.AssignProjectID(dir4)
+	require.NoError(t, err)
+	assert.EqualValues(t, baseProjectID+8, id)
+}
+
+func TestAssignProjectIDQuotaError(t *testing.T) {
+	root, err := ioutil.TempDir("", "quota-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+	c, _ := newTestControl(0)
+	c.getQuota = func(string, uint32) (*ifDqblk, error) { return nil, unix.EIO }
+	_, err = c.AssignProjectID(root)
+	assert.Error(t, err)
+	_, err = c.GetUsage(root)
+	assert.Error(t, err, "directory should not be assigned after failure")
+}
@@ -0,0 +1,356 @@
+/*
+Copyright 2018 The Containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/containerd/cri/pkg/atomic"
+)
+
+const (
+	// apparmorProfilesPath lists the apparmor profiles loaded in the kernel.
+	apparmorProfilesPath = "/sys/kernel/security/apparmor/profiles"
+	// apparmorRemovePath is the interface to unload an apparmor profile.
+	apparmorRemovePath = "/sys/kernel/security/apparmor/.remove"
+)
+
+// apparmorProfileNameRegexp matches profile declarations in apparmor profile
+// files, e.g. "profile name flags=(...) {" or "/usr/bin/foo {".
+var apparmorProfileNameRegexp = regexp.MustCompile(`^\s*(?:profile\s+([^\s{]+)|(/[^\s{]+))[^{]*\{`)
+
+// apparmorProfileFile is a loaded apparmor profile file.
+type apparmorProfileFile struct {
+	modTime time.Time
+	size    int64
+	// names are the names of profiles declared in the file.
+	names []string
+}
+
+// apparmorProfiles manages the apparmor profiles in the profile directory
+// and the default profile. Profile files are loaded at startup, reloaded
+// when they change, and profiles in removed files are unloaded.
+type apparmorProfiles struct {
+	// dir is the directory of apparmor profile files.
+	dir string
+	// defaultTemplate is the path of the default profile template.
+	defaultTemplate string
+	// syncPeriod is the period of profile directory sync.
+	syncPeriod time.Duration
+
+	// loadProfile loads or replaces the profiles in a file.
+	loadProfile func(path string) error
+	// unloadProfile unloads a profile by name.
+	unloadProfile func(name string) error
+	// loadedProfiles returns the names of loaded profiles.
+	loadedProfiles func() (map[string]bool, error)
+	// defaultLoaded is set once the default profile is loaded from the
+	// template. It is checked without the lock, so that container creation
+	// is not blocked by the sync.
+	defaultLoaded atomic.Bool
+
+	lock sync.Mutex
+	// files are the loaded profile files in the profile directory.
+	files map[string]apparmorProfileFile
+	// template is the loaded default profile template.
+	template apparmorProfileFile
+}
+
+// newApparmorProfiles creates an apparmor profile manager.
+func newApparmorProfiles(dir, defaultTemplate string, syncPeriod time.Duration) *apparmorProfiles {
+	return &apparmorProfiles{
+		dir:             dir,
+		defaultTemplate: defaultTemplate,
+		syncPeriod:      syncPeriod,
+		loadProfile:     loadApparmorProfile,
+		unloadProfile:   unloadApparmorProfile,
+		loadedProfiles:  loadedApparmorProfiles,
+		defaultLoaded:   atomic.NewBool(false),
+		files:           make(map[string]apparmorProfileFile),
+	}
+}
+
+// start syncs the profiles periodically. It doesn't need to be stopped.
+func (a *apparmorProfiles) start() {
+	if a.dir == "" && a.defaultTemplate == "" {
+		return
+	}
+	tick := time.NewTicker(a.syncPeriod)
+	go func() {
+		defer tick.Stop()
+		for {
+			<-tick.C
+			if err := a.sync(); err != nil {
+				logrus.WithError(err).Error("Failed to sync apparmor profiles")
+			}
+		}
+	}()
+}
+
+// sync loads new and changed profile files, unloads profiles of removed
+// files, and reloads the default profile if its template changes. A profile
+// file which fails to load is logged and retried once it changes; until then
+// only containers using its profiles fail to start. Error of the default profile is returned after the profile files are
+// synced, and the default profile is retried in the next sync.
+func (a *apparmorProfiles) sync() error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if err := a.syncDir(); err != nil {
+		return err
+	}
+	if a.defaultTemplate != "" {
+		if err := a.syncDefaultProfile(); err != nil {
+			return errors.Wrapf(err, "failed to load default apparmor profile template %q", a.defaultTemplate)
+		}
+	}
+	return nil
+}
+
+// syncDir syncs the profile files in the profile directory.
+func (a *apparmorProfiles) syncDir() error {
+	if a.dir == "" {
+		return nil
+	}
+	fis, err := ioutil.ReadDir(a.dir)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to read apparmor profile directory %q", a.dir)
+	}
+	current := make(map[string]bool)
+	for _, fi := range fis {
+		// Skip hidden files, e.g. editor swap files.
+		if !fi.Mode().IsRegular() || strings.HasPrefix(fi.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(a.dir, fi.Name())
+		current[path] = true
+		if f, ok := a.files[path]; ok && f.modTime.Equal(fi.ModTime()) && f.size == fi.Size() {
+			continue
+		}
+		if err := a.syncFile(path, fi); err != nil {
+			logrus.WithError(err).Errorf("Failed to load apparmor profile file %q", path)
+		}
+	}
+	for path, f := range a.files {
+		if current[path] {
+			continue
+		}
+		logrus.Infof("Unload apparmor profiles %v of removed file %q", f.names, path)
+		a.unload(f.names)
+		delete(a.files, path)
+	}
+	return nil
+}
+
+// syncFile loads a new or changed profile file, and unloads profiles which
+// are no longer declared in the file.
+func (a *apparmorProfiles) syncFile(path string, fi os.FileInfo) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to read file")
+	}
+	old := a.files[path]
+	// Record the file even if it fails to load, so that it is only retried
+	// after it is changed.
+	a.files[path] = apparmorProfileFile{modTime: fi.ModTime(), size: fi.Size(), names: old.names}
+	names := parseApparmorProfileNames(data)
+	if len(names) == 0 {
+		return errors.New("no profile is declared")
+	}
+	if err := a.loadProfile(path); err != nil {
+		return err
+	}
+	a.files[path] = apparmorProfileFile{modTime: fi.ModTime(), size: fi.Size(), names: names}
+	a.unload(subtractStrings(old.names, names))
+	logrus.Infof("Loaded apparmor profiles %v from %q", names, path)
+	return nil
+}
+
+// syncDefaultProfile loads the default profile generated from the template
+// if the template changes. The template is only recorded after the profile
+// is loaded, so that a failed template is retried.
+func (a *apparmorProfiles) syncDefaultProfile() error {
+	fi, err := os.Stat(a.defaultTemplate)
+	if err != nil {
+		return errors.Wrap(err, "failed to stat template")
+	}
+	if a.template.modTime.Equal(fi.ModTime()) && a.template.size == fi.Size() {
+		return nil
+	}
+	data, err := ioutil.ReadFile(a.defaultTemplate)
+	if err != nil {
+		return errors.Wrap(err, "failed to read template")
+	}
+	profile, err := generateApparmorProfile(string(data), appArmorDefaultProfileName)
+	if err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile("", appArmorDefaultProfileName)
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary profile file")
+	}
+	defer os.Remove(f.Name())
+	_, err = f.Write(profile)
+	f.Close()
+	if err != nil {
+		return errors.Wrap(err, "failed to write temporary profile file")
+	}
+	if err := a.loadProfile(f.Name()); err != nil {
+		return err
+	}
+	a.template = apparmorProfileFile{modTime: fi.ModTime(), size: fi.Size()}
+	a.defaultLoaded.Set()
+	logrus.Infof("Loaded default apparmor profile %q from template %q", appArmorDefaultProfileName, a.defaultTemplate)
+	return nil
+}
+
+// unload unloads the profiles, errors are only logged.
+func (a *apparmorProfiles) unload(names []string) {
+	for _, name := range names {
+		if err := a.unloadProfile(name); err != nil {
+			logrus.WithError(err).Errorf("Failed to unload apparmor profile %q", name)
+		}
+	}
+}
+
+// manageDefaultProfile returns true if the default profile is generated
+// from the configured template, and has been loaded. A previously loaded
+// profile is still used if the template is changed and fails to load.
+func (a *apparmorProfiles) manageDefaultProfile() bool {
+	return a != nil && a.defaultTemplate != "" && a.defaultLoaded.IsSet()
+}
+
+// validate returns error if the profile is not loaded.
+func (a *apparmorProfiles) validate(name string) error {
+	loaded, err := a.loadedProfiles()
+	if err != nil {
+		return errors.Wrap(err, "failed to get loaded apparmor profiles")
+	}
+	if !loaded[name] {
+		return errors.Errorf("apparmor profile %q is not loaded", name)
+	}
+	return nil
+}
+
+// generateApparmorProfile generates a profile from the template. The
+// template is a go template, and the profile name is `{{.Name}}`.
+func generateApparmorProfile(tmpl, name string) ([]byte, error) {
+	t, err := template.New("apparmor_profile").Parse(tmpl)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse template")
+	}
+	var b bytes.Buffer
+	if err := t.Execute(&b, struct{ Name string }{Name: name}); err != nil {
+		return nil, errors.Wrap(err, "failed to execute template")
+	}
+	names := parseApparmorProfileNames(b.Bytes())
+	if len(names) != 1 || names[0] != name {
+		return nil, errors.Errorf("template must declare exactly one profile {{.Name}}, got %v", names)
+	}
+	return b.Bytes(), nil
+}
+
+// parseApparmorProfileNames returns names of the profiles declared in an
+// apparmor profile file.
+func parseApparmorProfileNames(data []byte) []string {
+	var names []string
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		m := apparmorProfileNameRegexp.FindStringSubmatch(s.Text())
+		if m == nil {
+			continue
+		}
+		if m[1] != "" {
+			names = append(names, m[1])
+		} else {
+			names = append(names, m[2])
+		}
+	}
+	return names
+}
+
+// subtractStrings returns strings in a but not in b.
+func subtractStrings(a, b []string) []string {
+	var res []string
+	for _, s := range a {
+		found := false
+		for _, t := range b {
+			if s == t {
+				found = true
+				break
+			}
+		}
+		if !found {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+// loadApparmorProfile loads or replaces the profiles in the file with
+// apparmor_parser.
+func loadApparmorProfile(path string) error {
+	out, err := exec.Command("apparmor_parser", "-Kr", path).CombinedOutput()
+	if err != nil {
+		return errors.Wrapf(err, "apparmor_parser failed: %s", out)
+	}
+	return nil
+}
+
+// unloadApparmorProfile unloads the profile from the kernel. It is not an
+// error if the profile is not loaded.
+func unloadApparmorProfile(name string) error {
+	if err := ioutil.WriteFile(apparmorRemovePath, []byte(name), 0); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// loadedApparmorProfiles returns the names of profiles loaded in the kernel.
+func loadedApparmorProfiles() (map[string]bool, error) {
+	f, err := os.Open(apparmorProfilesPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	profiles := make(map[string]bool)
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		// Each line is in the form of "name (mode)".
+		line := s.Text()
+		if i := strings.LastIndex(line, " ("); i >= 0 {
+			line = line[:i]
+		}
+		profiles[line] = true
+	}
+	return profiles, s.Err()
+}
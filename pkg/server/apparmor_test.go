@@ -0,0 +1,199 @@
+/*
+Copyright 2018 The Containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApparmorDefaultProfileRetry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apparmor-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	template := filepath.Join(dir, "template")
+	require.NoError(t, ioutil.WriteFile(template, []byte("profile {{.Name}} {\n}\n"), 0644))
+
+	loadErr := errors.New("apparmor_parser failed")
+	a := newApparmorProfiles("", template, time.Second)
+	a.loadProfile = func(string) error { return loadErr }
+
+	t.Logf("should not manage default profile if it fails to load")
+	assert.Error(t, a.sync())
+	assert.False(t, a.manageDefaultProfile())
+
+	t.Logf("should retry unchanged template which failed to load")
+	loadErr = nil
+	require.NoError(t, a.sync())
+	assert.True(t, a.manageDefaultProfile())
+}
+
+func TestParseApparmorProfileNames(t *testing.T) {
+	for desc, test := range map[string]struct {
+		data     string
+		expected []string
+	}{
+		"should parse named profile": {
+			data:     "#include <tunables/global>\nprofile test-profile flags=(attach_disconnected) {\n  file,\n}\n",
+			expected: []string{"test-profile"},
+		},
+		"should parse attachment profile": {
+			data:     "/usr/bin/foo {\n  file,\n}\n",
+			expected: []string{"/usr/bin/foo"},
+		},
+		"should parse multiple profiles": {
+			data:     "profile a {\n}\nprofile b {\n}\n",
+			expected: []string{"a", "b"},
+		},
+		"should ignore rules and comments": {
+			data: "# profile comment {\n  /etc/** r,\n",
+		},
+	} {
+		t.Logf("TestCase %q", desc)
+		assert.Equal(t, test.expected, parseApparmorProfileNames([]byte(test.data)))
+	}
+}
+
+func TestGenerateApparmorProfile(t *testing.T) {
+	for desc, test := range map[string]struct {
+		template  string
+		expected  string
+		expectErr bool
+	}{
+		"should generate profile with name": {
+			template: "profile {{.Name}} {\n}\n",
+			expected: "profile test-default {\n}\n",
+		},
+		"should return error for invalid template": {
+			template:  "profile {{.Name {\n}\n",
+			expectErr: true,
+		},
+		"should return error if profile name is not the default name": {
+			template:  "profile other {\n}\n",
+			expectErr: true,
+		},
+		"should return error if multiple profiles are declared": {
+			template:  "profile {{.Name}} {\n}\nprofile other {\n}\n",
+			expectErr: true,
+		},
+	} {
+		t.Logf("TestCase %q", desc)
+		profile, err := generateApparmorProfile(test.template, "test-default")
+		assert.Equal(t, test.expectErr, err != nil)
+		if !test.expectErr {
+			assert.Equal(t, test.expected, string(profile))
+		}
+	}
+}
+
+func TestApparmorProfilesSync(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apparmor-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	profileDir := filepath.Join(dir, "profiles")
+	require.NoError(t, os.MkdirAll(profileDir, 0755))
+	template := filepath.Join(dir, "template")
+	require.NoError(t, ioutil.WriteFile(template, []byte("profile {{.Name}} {\n}\n"), 0644))
+
+	loaded := make(map[string]bool)
+	var loadedFiles []string
+	a := newApparmorProfiles(profileDir, template, time.Second)
+	a.loadProfile = func(path string) error {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if string(data) == "invalid {\n}\n" {
+			return errors.New("invalid profile")
+		}
+		for _, name := range parseApparmorProfileNames(data) {
+			loaded[name] = true
+		}
+		loadedFiles = append(loadedFiles, path)
+		return nil
+	}
+	a.unloadProfile = func(name string) error {
+		delete(loaded, name)
+		return nil
+	}
+	loadedNames := func() []string {
+		var names []string
+		for name := range loaded {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names
+	}
+	writeProfile := func(name, data string, modTime time.Time) {
+		path := filepath.Join(profileDir, name)
+		require.NoError(t, ioutil.WriteFile(path, []byte(data), 0644))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+	now := time.Now()
+
+	t.Logf("should load default profile and profile files")
+	writeProfile("a", "profile a {\n}\nprofile b {\n}\n", now)
+	writeProfile("c", "profile c {\n}\n", now)
+	writeProfile(".swap", "profile swap {\n}\n", now)
+	writeProfile("invalid", "invalid {\n}\n", now)
+	require.NoError(t, a.sync())
+	assert.Equal(t, []string{"a", "b", "c", appArmorDefaultProfileName}, loadedNames())
+
+	t.Logf("should not reload unchanged files")
+	loadedFiles = nil
+	require.NoError(t, a.sync())
+	assert.Empty(t, loadedFiles)
+
+	t.Logf("should reload changed file and unload removed profiles")
+	writeProfile("a", "profile a {\n  file,\n}\n", now.Add(time.Hour))
+	require.NoError(t, a.sync())
+	assert.Equal(t, []string{filepath.Join(profileDir, "a")}, loadedFiles)
+	assert.Equal(t, []string{"a", "c", appArmorDefaultProfileName}, loadedNames())
+
+	t.Logf("should unload profiles of removed file")
+	require.NoError(t, os.Remove(filepath.Join(profileDir, "c")))
+	require.NoError(t, a.sync())
+	assert.Equal(t, []string{"a", appArmorDefaultProfileName}, loadedNames())
+
+	t.Logf("should fail and keep using the loaded default profile if template is invalid")
+	writeTemplate := func(data string, modTime time.Time) {
+		require.NoError(t, ioutil.WriteFile(template, []byte(data), 0644))
+		require.NoError(t, os.Chtimes(template, modTime, modTime))
+	}
+	writeTemplate("profile {{.Invalid}} {\n}\n", now.Add(time.Hour))
+	assert.Error(t, a.sync())
+	assert.True(t, a.manageDefaultProfile())
+
+	t.Logf("should reload default profile after template is fixed")
+	loadedFiles = nil
+	writeTemplate("profile {{.Name}} {\n  file,\n}\n", now.Add(2*time.Hour))
+	require.NoError(t, a.sync())
+	assert.Len(t, loadedFiles, 1)
+
+	t.Logf("should validate loaded profiles")
+	a.loadedProfiles = func() (map[string]bool, error) { return loaded, nil }
+	assert.NoError(t, a.validate("a"))
+	assert.Error(t, a.validate("c"))
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2018 The Containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	tasks "github.com/containerd/containerd/api/services/tasks/v1"
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/diff"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/linux/runctypes"
+	"github.com/containerd/containerd/rootfs"
+	"github.com/containerd/typeurl"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
+
+	api "github.com/containerd/cri/pkg/api/v1"
+	ctrdutil "github.com/containerd/cri/pkg/containerd/util"
+	containerstore "github.com/containerd/cri/pkg/store/container"
+)
+
+const (
+	// checkpointVersion is the version of the checkpoint archive format.
+	checkpointVersion = "v1"
+	// checkpointMetadataFile is the name of the metadata file in the
+	// checkpoint archive.
+	checkpointMetadataFile = "metadata.json"
+	// checkpointCRIUFile is the name of the CRIU image file in the
+	// checkpoint archive.
+	checkpointCRIUFile = "criu.tar"
+	// checkpointRWLayerFile is the name of the writable layer diff file
+	// in the checkpoint archive.
+	checkpointRWLayerFile = "rw.tar"
+)
+
+// checkpointMetadata is the metadata of a container checkpoint, it is the
+// first file in the checkpoint archive.
+type checkpointMetadata struct {
+	// Version is the version of the checkpoint archive format.
+	Version string `json:"version"`
+	// ID is the id of the checkpointed container.
+	ID string `json:"id"`
+	// Config is the CRI container config of the checkpointed container.
+	Config *runtime.ContainerConfig `json:"config"`
+	// ImageRef is the id of the image the container is created from.
+	ImageRef string `json:"imageRef"`
+	// RuntimeType is the runtime type the container is checkpointed on.
+	RuntimeType string `json:"runtimeType"`
+	// CheckpointedAt is the time the container is checkpointed.
+	CheckpointedAt time.Time `json:"checkpointedAt"`
+	// CRIU is the descriptor of the CRIU image.
+	CRIU ocispec.Descriptor `json:"criu"`
+	// RWLayer is the descriptor of the writable layer diff.
+	RWLayer ocispec.Descriptor `json:"rwLayer"`
+}
+
+// CheckpointContainer checkpoints a running container with CRIU, and writes
+// the CRIU image, the writable layer diff and the container metadata into
+// an archive.
+func (c *criService) CheckpointContainer(ctx context.Context, r *api.CheckpointContainerRequest) (*api.CheckpointContainerResponse, error) {
+	path := r.GetPath()
+	if !filepath.IsAbs(path) {
+		return nil, errors.Errorf("path %q is not an absolute path", path)
+	}
+	cntr, err := c.containerStore.Get(r.GetContainerId())
+	if err != nil {
+		return nil, errors.Wrapf(err, "an error occurred when try to find container %q", r.GetContainerId())
+	}
+	sandbox, err := c.sandboxStore.Get(cntr.SandboxID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find sandbox %q", cntr.SandboxID)
+	}
+	ociRuntime, err := c.getSandboxRuntimeConfig(ctx, sandbox)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get runtime of sandbox %q", sandbox.ID)
+	}
+
+	// Hold a lease, so that the checkpoint content is not garbage collected
+	// before it is written into the archive.
+	ctx, done, err := c.client.WithLease(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create lease")
+	}
+	defer done()
+
+	// Checkpoint in the status transaction, so that the container is not
+	// paused or resumed by others in the middle.
+	var meta *checkpointMetadata
+	if err := cntr.Status.UpdateSync(func(status containerstore.Status) (containerstore.Status, error) {
+		id := cntr.ID
+		if state := status.State(); state != runtime.ContainerState_CONTAINER_RUNNING {
+			return status, errors.Errorf("container %q is in %s state", id, criContainerStateToString(state))
+		}
+		if status.Removing {
+			return status, errors.Errorf("container %q is in removing state", id)
+		}
+		if status.Paused {
+			return status, errors.Errorf("container %q is paused", id)
+		}
+		var err error
+		meta, err = c.checkpointContainer(ctx, cntr, r.GetExit())
+		return status, err
+	}); err != nil {
+		return nil, err
+	}
+	meta.RuntimeType = ociRuntime.Type
+	if err := writeCheckpointArchive(ctx, c.client.ContentStore(), path, meta); err != nil {
+		return nil, errors.Wrapf(err, "failed to write checkpoint archive %q", path)
+	}
+	return &api.CheckpointContainerResponse{}, nil
+}
+
+// checkpointContainer checkpoints the container task and its writable layer
+// into the content store. The container is paused during the checkpoint, and
+// resumed afterwards unless it is checkpointed with exit. It must be called
+// in the container status transaction.
+func (c *criService) checkpointContainer(ctx context.Context, cntr containerstore.Container, exit bool) (*checkpointMetadata, error) {
+	id := cntr.ID
+	task, err := cntr.Container.Task(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get task for container %q", id)
+	}
+	opts, err := typeurl.MarshalAny(&runctypes.CheckpointOptions{Exit: exit})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal checkpoint options")
+	}
+
+	// Pause the container, so that the writable layer is consistent with
+	// the CRIU image.
+	if err := task.Pause(ctx); err != nil {
+		return nil, errors.Wrapf(err, "failed to pause container %q", id)
+	}
+	exited := false
+	defer func() {
+		if exited {
+			return
+		}
+		deferCtx, deferCancel := ctrdutil.DeferContext()
+		defer deferCancel()
+		if err := task.Resume(deferCtx); err != nil {
+			logrus.WithError(err).Errorf("Failed to resume container %q after checkpoint", id)
+		}
+	}()
+
+	resp, err := c.client.TaskService().Checkpoint(ctx, &tasks.CheckpointTaskRequest{
+		ContainerID: id,
+		Options:     opts,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to checkpoint container %q", id)
+	}
+	exited = exit
+	meta := &checkpointMetadata{
+		Version:        checkpointVersion,
+		ID:             id,
+		Config:         cntr.Config,
+		ImageRef:       cntr.ImageRef,
+		CheckpointedAt: time.Now(),
+	}
+	for _, d := range resp.Descriptors {
+		if d.MediaType == images.MediaTypeContainerd1Checkpoint {
+			meta.CRIU = ocispec.Descriptor{
+				MediaType: d.MediaType,
+				Digest:    d.Digest,
+				Size:      d.Size_,
+			}
+		}
+	}
+	if meta.CRIU.Digest == "" {
+		return nil, errors.Errorf("criu image of container %q not found in checkpoint", id)
+	}
+
+	rw, err := rootfs.CreateDiff(ctx, id, c.client.SnapshotService(cntr.Snapshotter), c.client.DiffService(),
+		diff.WithReference("checkpoint-rw-"+id))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create writable layer diff of container %q", id)
+	}
+	meta.RWLayer = rw
+	return meta, nil
+}
+
+// writeCheckpointArchive writes the checkpoint metadata and content into
+// an archive at the path. The archive is written into a temporary file
+// first, and renamed to the path when it is complete.
+func writeCheckpointArchive(ctx context.Context, cs content.Provider, path string, meta *checkpointMetadata) (retErr error) {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal checkpoint metadata")
+	}
+	f, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path))
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary file")
+	}
+	defer func() {
+		if retErr != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+	tw := tar.NewWriter(f)
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     checkpointMetadataFile,
+		Mode:     0644,
+		Size:     int64(len(data)),
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to write %q header", checkpointMetadataFile)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return errors.Wrapf(err, "failed to write %q", checkpointMetadataFile)
+	}
+	if err := writeCheckpointBlob(ctx, cs, tw, checkpointCRIUFile, meta.CRIU); err != nil {
+		return err
+	}
+	if err := writeCheckpointBlob(ctx, cs, tw, checkpointRWLayerFile, meta.RWLayer); err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return errors.Wrap(err, "failed to close archive")
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrap(err, "failed to close temporary file")
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// writeCheckpointBlob writes a blob in the content store into the archive.
+func writeCheckpointBlob(ctx context.Context, cs content.Provider, tw *tar.Writer, name string, desc ocispec.Descriptor) error {
+	ra, err := cs.ReaderAt(ctx, desc.Digest)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get reader of %v", desc.Digest)
+	}
+	defer ra.Close()
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0644,
+		Size:     desc.Size,
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to write %q header", name)
+	}
+	if _, err := io.Copy(tw, content.NewReader(ra)); err != nil {
+		return errors.Wrapf(err, "failed to write %q", name)
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2018 The Containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/content/local"
+	"github.com/containerd/containerd/images"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
+)
+
+// writeTestBlob writes the data into the content store.
+func writeTestBlob(ctx context.Context, t *testing.T, cs content.Ingester, mediaType string, data []byte) ocispec.Descriptor {
+	desc := ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    digest.FromBytes(data),
+		Size:      int64(len(data)),
+	}
+	require.NoError(t, content.WriteBlob(ctx, cs, "test-"+desc.Digest.String(), bytes.NewReader(data), desc.Size, desc.Digest))
+	return desc
+}
+
+// testArchiveFile is a file in a test archive.
+type testArchiveFile struct {
+	name string
+	data []byte
+}
+
+// writeTestArchive writes a tar archive with the files in order.
+func writeTestArchive(t *testing.T, path string, files []testArchiveFile) {
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	for _, f := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     f.name,
+			Mode:     0644,
+			Size:     int64(len(f.data)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write(f.data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, ioutil.WriteFile(path, buf.Bytes(), 0644))
+}
+
+func TestCheckpointArchiveRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "checkpoint-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	src, err := local.NewStore(filepath.Join(dir, "src"))
+	require.NoError(t, err)
+	dst, err := local.NewStore(filepath.Join(dir, "dst"))
+	require.NoError(t, err)
+
+	criuData := []byte("test-criu-image")
+	rwData := []byte("test-rw-layer")
+	meta := &checkpointMetadata{
+		Version: checkpointVersion,
+		ID:      "test-id",
+		Config: &runtime.ContainerConfig{
+			Metadata: &runtime.ContainerMetadata{Name: "test-name"},
+			Image:    &runtime.ImageSpec{Image: "busybox"},
+		},
+		ImageRef:       "sha256:test-image",
+		RuntimeType:    "io.containerd.runtime.v1.linux",
+		CheckpointedAt: time.Unix(100, 0).UTC(),
+		CRIU:           writeTestBlob(ctx, t, src, images.MediaTypeContainerd1Checkpoint, criuData),
+		RWLayer:        writeTestBlob(ctx, t, src, ocispec.MediaTypeImageLayer, rwData),
+	}
+	path := filepath.Join(dir, "checkpoint.tar")
+	require.NoError(t, writeCheckpointArchive(ctx, src, path, meta))
+
+	got, err := readCheckpointArchive(ctx, dst, path)
+	require.NoError(t, err)
+	assert.Equal(t, meta, got)
+	for dgst, data := range map[digest.Digest][]byte{
+		meta.CRIU.Digest:    criuData,
+		meta.RWLayer.Digest: rwData,
+	} {
+		b, err := content.ReadBlob(ctx, dst, dgst)
+		require.NoError(t, err)
+		assert.Equal(t, data, b)
+	}
+}
+
+func TestReadCheckpointArchive(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "checkpoint-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	criuData := []byte("test-criu-image")
+	rwData := []byte("test-rw-layer")
+	newMeta := func(version string, config *runtime.ContainerConfig) []byte {
+		data, err := json.Marshal(&checkpointMetadata{
+			Version: version,
+			ID:      "test-id",
+			Config:  config,
+			CRIU: ocispec.Descriptor{
+				MediaType: images.MediaTypeContainerd1Checkpoint,
+				Digest:    digest.FromBytes(criuData),
+				Size:      int64(len(criuData)),
+			},
+			RWLayer: ocispec.Descriptor{
+				MediaType: ocispec.MediaTypeImageLayer,
+				Digest:    digest.FromBytes(rwData),
+				Size:      int64(len(rwData)),
+			},
+		})
+		require.NoError(t, err)
+		return data
+	}
+	config := &runtime.ContainerConfig{Metadata: &runtime.ContainerMetadata{Name: "test-name"}}
+	for desc, test := range map[string]struct {
+		files     []testArchiveFile
+		expectErr bool
+	}{
+		"should read archive with unknown files": {
+			files: []testArchiveFile{
+				{name: checkpointMetadataFile, data: newMeta(checkpointVersion, config)},
+				{name: checkpointRWLayerFile, data: rwData},
+				{name: "unknown", data: []byte("unknown")},
+				{name: checkpointCRIUFile, data: criuData},
+			},
+		},
+		"should fail if metadata is not the first file": {
+			files: []testArchiveFile{
+				{name: checkpointCRIUFile, data: criuData},
+				{name: checkpointMetadataFile, data: newMeta(checkpointVersion, config)},
+				{name: checkpointRWLayerFile, data: rwData},
+			},
+			expectErr: true,
+		},
+		"should fail if version is not supported": {
+			files: []testArchiveFile{
+				{name: checkpointMetadataFile, data: newMeta("v0", config)},
+				{name: checkpointCRIUFile, data: criuData},
+				{name: checkpointRWLayerFile, data: rwData},
+			},
+			expectErr: true,
+		},
+		"should fail if container config is missing": {
+			files: []testArchiveFile{
+				{name: checkpointMetadataFile, data: newMeta(checkpointVersion, nil)},
+				{name: checkpointCRIUFile, data: criuData},
+				{name: checkpointRWLayerFile, data: rwData},
+			},
+			expectErr: true,
+		},
+		"should fail if blob is missing": {
+			files: []testArchiveFile{
+				{name: checkpointMetadataFile, data: newMeta(checkpointVersion, config)},
+				{name: checkpointCRIUFile, data: criuData},
+			},
+			expectErr: true,
+		},
+		"should fail if blob doesn't match digest": {
+			files: []testArchiveFile{
+				{name: checkpointMetadataFile, data: newMeta(checkpointVersion, config)},
+				{name: checkpointCRIUFile, data: []byte("test-criu-imagE")},
+				{name: checkpointRWLayerFile, data: rwData},
+			},
+			expectErr: true,
+		},
+	} {
+		t.Logf("TestCase %q", desc)
+		cs, err := local.NewStore(filepath.Join(dir, "content-"+digest.FromString(desc).Hex()))
+		require.NoError(t, err)
+		path := filepath.Join(dir, "checkpoint.tar")
+		writeTestArchive(t, path, test.files)
+		_, err = readCheckpointArchive(ctx, cs, path)
+		assert.Equal(t, test.expectErr, err != nil)
+	}
+}
@@ -19,6 +19,7 @@ package server
 import (
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -36,15 +37,14 @@ import (
 	runtimespec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/opencontainers/runtime-tools/generate"
 	"github.com/opencontainers/runtime-tools/validate"
-	"github.com/opencontainers/selinux/go-selinux/label"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/syndtr/gocapability/capability"
 	"golang.org/x/net/context"
-	"golang.org/x/sys/unix"
 	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
 
 	"github.com/containerd/cri/pkg/annotations"
+	criconfig "github.com/containerd/cri/pkg/config"
 	customopts "github.com/containerd/cri/pkg/containerd/opts"
 	ctrdutil "github.com/containerd/cri/pkg/containerd/util"
 	cio "github.com/containerd/cri/pkg/server/io"
@@ -123,15 +123,16 @@ func (c *criService) CreateContainer(ctx context.Context, r *runtime.CreateConta
 	}
 
 	// Run container using the same runtime with sandbox.
-	sandboxInfo, err := sandbox.Container.Info(ctx)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to get sandbox %q info", sandboxID)
-	}
-	ociRuntime, err := getRuntimeConfigFromContainerInfo(sandboxInfo)
+	ociRuntime, err := c.getSandboxRuntimeConfig(ctx, sandbox)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get OCI runtime")
 	}
-	logrus.Debugf("Use OCI %+v for container %q", ociRuntime, id)
+	logrus.Debugf("Use OCI runtime %q %+v for container %q", sandbox.RuntimeHandler, ociRuntime, id)
+	meta.Snapshotter = c.getSandboxSnapshotter(sandbox)
+	// The image may not be unpacked for the snapshotter of the sandbox yet.
+	if err := c.ensureImageUnpacked(ctx, image, meta.Snapshotter); err != nil {
+		return nil, errors.Wrapf(err, "failed to unpack image %q", imageRef)
+	}
 
 	// Create container root directory.
 	containerRootDir := c.getContainerRootDir(id)
@@ -169,22 +170,35 @@ func (c *criService) CreateContainer(ctx context.Context, r *runtime.CreateConta
 	// Generate container runtime spec.
 	mounts := c.generateContainerMounts(sandboxID, config)
 
-	spec, err := c.generateContainerSpec(id, sandboxID, sandboxPid, config, sandboxConfig, &image.ImageSpec.Config, append(mounts, volumeMounts...))
+	spec, err := c.generateContainerSpec(id, sandboxID, sandboxPid, config, sandboxConfig, ociRuntime, &image.ImageSpec.Config, append(mounts, volumeMounts...))
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to generate container %q spec", id)
 	}
+	meta.Hooks = c.ociHooks.apply(spec, config.GetAnnotations(), len(config.GetMounts()) > 0)
 
 	logrus.Debugf("Container %q spec: %#+v", id, spew.NewFormatter(spec))
 
+	// Prepare container rootfs. This is always writeable even if
+	// the container wants a readonly rootfs since we want to give
+	// the runtime (runc) a chance to modify (e.g. to create mount
+	// points corresponding to spec.Mounts) before making the
+	// rootfs readonly (requested by spec.Root.Readonly).
+	snapshotOpt := customopts.WithNewSnapshot(id, image.Image)
+	if userns := sandbox.UserNamespace; userns != nil {
+		// The container runs in the user namespace of the sandbox, the
+		// rootfs and image volumes must be owned by the mapped ids.
+		snapshotOpt = customopts.WithRemappedSnapshot(id, image.Image, userns.HostID, userns.HostID, userns.Size)
+		for _, v := range volumeMounts {
+			if err := c.os.Lchown(v.HostPath, int(userns.HostID), int(userns.HostID)); err != nil {
+				return nil, errors.Wrapf(err, "failed to change ownership of volume %q", v.HostPath)
+			}
+		}
+	}
+
 	// Set snapshotter before any other options.
 	opts := []containerd.NewContainerOpts{
-		containerd.WithSnapshotter(c.config.ContainerdConfig.Snapshotter),
-		// Prepare container rootfs. This is always writeable even if
-		// the container wants a readonly rootfs since we want to give
-		// the runtime (runc) a chance to modify (e.g. to create mount
-		// points corresponding to spec.Mounts) before making the
-		// rootfs readonly (requested by spec.Root.Readonly).
-		customopts.WithNewSnapshot(id, image.Image),
+		containerd.WithSnapshotter(meta.Snapshotter),
+		snapshotOpt,
 	}
 
 	if len(volumeMounts) > 0 {
@@ -196,6 +210,11 @@ func (c *criService) CreateContainer(ctx context.Context, r *runtime.CreateConta
 	}
 	meta.ImageRef = image.ID
 
+	meta.WritableLayerLimit, err = getWritableLayerLimit(config, ociRuntime)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get writable layer limit")
+	}
+
 	// Get container log path.
 	if config.GetLogPath() != "" {
 		meta.LogPath = filepath.Join(sandbox.Config.GetLogDirectory(), config.GetLogPath())
@@ -229,7 +248,8 @@ func (c *criService) CreateContainer(ctx context.Context, r *runtime.CreateConta
 	apparmorSpecOpts, err := generateApparmorSpecOpts(
 		securityContext.GetApparmorProfile(),
 		securityContext.GetPrivileged(),
-		c.apparmorEnabled)
+		c.apparmorEnabled,
+		c.apparmorProfiles)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to generate apparmor spec opts")
 	}
@@ -240,13 +260,22 @@ func (c *criService) CreateContainer(ctx context.Context, r *runtime.CreateConta
 	seccompSpecOpts, err := generateSeccompSpecOpts(
 		securityContext.GetSeccompProfilePath(),
 		securityContext.GetPrivileged(),
-		c.seccompEnabled)
+		c.seccompEnabled,
+		c.seccompProfiles)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to generate seccomp spec opts")
 	}
 	if seccompSpecOpts != nil {
 		specOpts = append(specOpts, seccompSpecOpts)
 	}
+	if userns := sandbox.UserNamespace; userns != nil {
+		specOpts = append(specOpts,
+			oci.WithLinuxNamespace(runtimespec.LinuxNamespace{
+				Type: runtimespec.UserNamespace,
+				Path: getUserNamespace(sandboxPid),
+			}),
+			oci.WithUserNamespace(0, userns.HostID, userns.Size))
+	}
 	containerLabels := buildLabels(config.Labels, containerKindContainer)
 
 	opts = append(opts,
@@ -256,7 +285,8 @@ func (c *criService) CreateContainer(ctx context.Context, r *runtime.CreateConta
 			&runctypes.RuncOptions{
 				Runtime:       ociRuntime.Engine,
 				RuntimeRoot:   ociRuntime.Root,
-				SystemdCgroup: c.config.SystemdCgroup}), // TODO (mikebrow): add CriuPath when we add support for pause
+				SystemdCgroup: ociRuntime.SystemdCgroup,
+				CriuPath:      ociRuntime.CriuPath}),
 		containerd.WithContainerLabels(containerLabels),
 		containerd.WithContainerExtension(containerMetadataExtension, &meta))
 	var cntr containerd.Container
@@ -273,6 +303,16 @@ func (c *criService) CreateContainer(ctx context.Context, r *runtime.CreateConta
 		}
 	}()
 
+	// Enforce writable layer limit with project quota if possible, or else
+	// the writable layer limit checker kills the container after it exceeds
+	// the limit. Project quota is only supported for the global snapshotter.
+	if meta.WritableLayerLimit > 0 && c.quotaControl != nil &&
+		meta.Snapshotter == c.config.ContainerdConfig.Snapshotter {
+		if err := c.setWritableLayerQuota(ctx, id, meta.WritableLayerLimit); err != nil {
+			return nil, errors.Wrap(err, "failed to set writable layer quota")
+		}
+	}
+
 	status := containerstore.Status{CreatedAt: time.Now().UnixNano()}
 	container, err := containerstore.NewContainer(meta,
 		containerstore.WithStatus(status, containerRootDir),
@@ -296,11 +336,14 @@ func (c *criService) CreateContainer(ctx context.Context, r *runtime.CreateConta
 		return nil, errors.Wrapf(err, "failed to add container %q into store", id)
 	}
 
+	c.markImageUsed(ctx, image)
+
 	return &runtime.CreateContainerResponse{ContainerId: id}, nil
 }
 
 func (c *criService) generateContainerSpec(id string, sandboxID string, sandboxPid uint32, config *runtime.ContainerConfig,
-	sandboxConfig *runtime.PodSandboxConfig, imageConfig *imagespec.ImageConfig, extraMounts []*runtime.Mount) (*runtimespec.Spec, error) {
+	sandboxConfig *runtime.PodSandboxConfig, ociRuntime criconfig.Runtime, imageConfig *imagespec.ImageConfig,
+	extraMounts []*runtime.Mount) (*runtimespec.Spec, error) {
 	// Creates a spec Generator with the default spec.
 	spec, err := defaultRuntimeSpec(id)
 	if err != nil {
@@ -345,6 +388,11 @@ func (c *criService) generateContainerSpec(id string, sandboxID string, sandboxP
 
 	// Add extra mounts first so that CRI specified mounts can override.
 	mounts := append(extraMounts, config.GetMounts()...)
+	// Add tmpfs mounts before bind mounts, so that paths under tmpfs mounts
+	// can still be bind mounted.
+	if err := addOCITmpfsMounts(&g, config, ociRuntime.TmpfsMounts, mounts); err != nil {
+		return nil, errors.Wrap(err, "failed to set OCI tmpfs mounts")
+	}
 	if err := c.addOCIBindMounts(&g, mounts, mountLabel); err != nil {
 		return nil, errors.Wrapf(err, "failed to set OCI bind mounts %+v", mounts)
 	}
@@ -381,7 +429,7 @@ func (c *criService) generateContainerSpec(id string, sandboxID string, sandboxP
 
 	if sandboxConfig.GetLinux().GetCgroupParent() != "" {
 		cgroupsPath := getCgroupsPath(sandboxConfig.GetLinux().GetCgroupParent(), id,
-			c.config.SystemdCgroup)
+			ociRuntime.SystemdCgroup)
 		g.SetLinuxCgroupsPath(cgroupsPath)
 	}
 
@@ -422,7 +470,9 @@ func (c *criService) generateVolumeMounts(containerRootDir string, criMounts []*
 			ContainerPath: dst,
 			HostPath:      src,
 			// Use default mount propagation.
-			// TODO(random-liu): What about selinux relabel?
+			// Relabel the volume with the container mount label, so that
+			// the container can access it when selinux is enabled.
+			SelinuxRelabel: true,
 		})
 	}
 	return mounts
@@ -642,8 +692,8 @@ func (c *criService) addOCIBindMounts(g *generate.Generator, mounts []*runtime.M
 		}
 
 		if mount.GetSelinuxRelabel() {
-			if err := label.Relabel(src, mountLabel, true); err != nil && err != unix.ENOTSUP {
-				return errors.Wrapf(err, "relabel %q with %q failed", src, mountLabel)
+			if err := relabelPath(src, mountLabel, true); err != nil {
+				return err
 			}
 		}
 		g.AddBindMount(src, dst, options)
@@ -652,6 +702,37 @@ func (c *criService) addOCIBindMounts(g *generate.Generator, mounts []*runtime.M
 	return nil
 }
 
+// addOCITmpfsMounts adds the tmpfs mounts of the runtime into the spec unless
+// they are disabled by the container annotation. Paths with a CRI mount are
+// skipped. The existing content in the image is copied up into the tmpfs by
+// the runtime with the "tmpcopyup" option.
+func addOCITmpfsMounts(g *generate.Generator, config *runtime.ContainerConfig, tmpfsMounts []string, mounts []*runtime.Mount) error {
+	if value, ok := config.GetAnnotations()[annotations.DisableTmpfsMounts]; ok {
+		disabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse annotation %q", annotations.DisableTmpfsMounts)
+		}
+		if disabled {
+			return nil
+		}
+	}
+	for _, dst := range tmpfsMounts {
+		dst = filepath.Clean(dst)
+		overridden := false
+		for _, m := range mounts {
+			if filepath.Clean(m.GetContainerPath()) == dst {
+				overridden = true
+				break
+			}
+		}
+		if overridden {
+			continue
+		}
+		g.AddTmpfsMount(dst, []string{"nosuid", "nodev", "noexec", "mode=755", "tmpcopyup"})
+	}
+	return nil
+}
+
 func setOCIBindMountsPrivileged(g *generate.Generator) {
 	spec := g.Spec()
 	// clear readonly for /sys and cgroup
@@ -759,8 +840,8 @@ func defaultRuntimeSpec(id string) (*runtimespec.Spec, error) {
 		return nil, err
 	}
 
-	// Remove `/run` mount
-	// TODO(random-liu): Mount tmpfs for /run and handle copy-up.
+	// Remove `/run` mount, tmpfs mounts are added by the runtime config
+	// (see addOCITmpfsMounts).
 	var mounts []runtimespec.Mount
 	for _, mount := range spec.Mounts {
 		if mount.Destination == "/run" {
@@ -785,12 +866,16 @@ func defaultRuntimeSpec(id string) (*runtimespec.Spec, error) {
 }
 
 // generateSeccompSpecOpts generates containerd SpecOpts for seccomp.
-func generateSeccompSpecOpts(seccompProf string, privileged, seccompEnabled bool) (oci.SpecOpts, error) {
+func generateSeccompSpecOpts(seccompProf string, privileged, seccompEnabled bool, profiles *seccompProfiles) (oci.SpecOpts, error) {
 	if privileged {
 		// Do not set seccomp profile when container is privileged
 		return nil, nil
 	}
-	// Set seccomp profile
+	// Set seccomp profile. The default profile is only applied when seccomp
+	// is supported, so that containers can still be created when it is not.
+	if seccompProf == "" && profiles.applyDefault && seccompEnabled {
+		seccompProf = runtimeDefault
+	}
 	if seccompProf == runtimeDefault || seccompProf == dockerDefault {
 		// use correct default profile (Eg. if not configured otherwise, the default is docker/default)
 		seccompProf = seccompDefaultProfile
@@ -806,6 +891,9 @@ func generateSeccompSpecOpts(seccompProf string, privileged, seccompEnabled bool
 		// Do not set seccomp profile.
 		return nil, nil
 	case dockerDefault:
+		if profiles.defaultProfile != nil {
+			return customopts.WithSeccompProfile(profiles.defaultProfile), nil
+		}
 		// Note: WithDefaultProfile specOpts must be added after capabilities
 		return seccomp.WithDefaultProfile(), nil
 	default:
@@ -813,12 +901,20 @@ func generateSeccompSpecOpts(seccompProf string, privileged, seccompEnabled bool
 		if !strings.HasPrefix(seccompProf, profileNamePrefix) {
 			return nil, errors.Errorf("invalid seccomp profile %q", seccompProf)
 		}
-		return seccomp.WithProfile(strings.TrimPrefix(seccompProf, profileNamePrefix)), nil
+		path, err := profiles.resolve(strings.TrimPrefix(seccompProf, profileNamePrefix))
+		if err != nil {
+			return nil, err
+		}
+		profile, err := profiles.get(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load seccomp profile %q", seccompProf)
+		}
+		return customopts.WithSeccompProfile(profile), nil
 	}
 }
 
 // generateApparmorSpecOpts generates containerd SpecOpts for apparmor.
-func generateApparmorSpecOpts(apparmorProf string, privileged, apparmorEnabled bool) (oci.SpecOpts, error) {
+func generateApparmorSpecOpts(apparmorProf string, privileged, apparmorEnabled bool, profiles *apparmorProfiles) (oci.SpecOpts, error) {
 	if !apparmorEnabled {
 		// Should fail loudly if user try to specify apparmor profile
 		// but we don't support it.
@@ -829,6 +925,9 @@ func generateApparmorSpecOpts(apparmorProf string, privileged, apparmorEnabled b
 	}
 	switch apparmorProf {
 	case runtimeDefault:
+		if profiles.manageDefaultProfile() {
+			return apparmor.WithProfile(appArmorDefaultProfileName), nil
+		}
 		// TODO (mikebrow): delete created apparmor default profile
 		return apparmor.WithDefaultProfile(appArmorDefaultProfileName), nil
 	case unconfinedProfile:
@@ -839,13 +938,24 @@ func generateApparmorSpecOpts(apparmorProf string, privileged, apparmorEnabled b
 		if privileged {
 			return nil, nil
 		}
+		if profiles.manageDefaultProfile() {
+			return apparmor.WithProfile(appArmorDefaultProfileName), nil
+		}
 		return apparmor.WithDefaultProfile(appArmorDefaultProfileName), nil
 	default:
 		// Require and Trim default profile name prefix
 		if !strings.HasPrefix(apparmorProf, profileNamePrefix) {
 			return nil, errors.Errorf("invalid apparmor profile %q", apparmorProf)
 		}
-		return apparmor.WithProfile(strings.TrimPrefix(apparmorProf, profileNamePrefix)), nil
+		name := strings.TrimPrefix(apparmorProf, profileNamePrefix)
+		// Validate the profile here, or else the container fails to start
+		// in the runtime.
+		if profiles != nil {
+			if err := profiles.validate(name); err != nil {
+				return nil, err
+			}
+		}
+		return apparmor.WithProfile(name), nil
 	}
 }
 
@@ -17,6 +17,8 @@ limitations under the License.
 package server
 
 import (
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"reflect"
 	"testing"
@@ -33,6 +35,9 @@ import (
 	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
 
 	"github.com/containerd/cri/pkg/annotations"
+	"github.com/containerd/cri/pkg/atomic"
+	criconfig "github.com/containerd/cri/pkg/config"
+	customopts "github.com/containerd/cri/pkg/containerd/opts"
 	ostesting "github.com/containerd/cri/pkg/os/testing"
 	"github.com/containerd/cri/pkg/util"
 )
@@ -190,7 +195,7 @@ func TestGeneralContainerSpec(t *testing.T) {
 	config, sandboxConfig, imageConfig, specCheck := getCreateContainerTestData()
 	c := newTestCRIService()
 	testSandboxID := "sandbox-id"
-	spec, err := c.generateContainerSpec(testID, testSandboxID, testPid, config, sandboxConfig, imageConfig, nil)
+	spec, err := c.generateContainerSpec(testID, testSandboxID, testPid, config, sandboxConfig, criconfig.Runtime{}, imageConfig, nil)
 	require.NoError(t, err)
 	specCheck(t, testID, testSandboxID, testPid, spec)
 }
@@ -245,7 +250,7 @@ func TestContainerCapabilities(t *testing.T) {
 	} {
 		t.Logf("TestCase %q", desc)
 		config.Linux.SecurityContext.Capabilities = test.capability
-		spec, err := c.generateContainerSpec(testID, testSandboxID, testPid, config, sandboxConfig, imageConfig, nil)
+		spec, err := c.generateContainerSpec(testID, testSandboxID, testPid, config, sandboxConfig, criconfig.Runtime{}, imageConfig, nil)
 		require.NoError(t, err)
 		specCheck(t, testID, testSandboxID, testPid, spec)
 		t.Log(spec.Process.Capabilities.Bounding)
@@ -272,7 +277,7 @@ func TestContainerSpecTty(t *testing.T) {
 	c := newTestCRIService()
 	for _, tty := range []bool{true, false} {
 		config.Tty = tty
-		spec, err := c.generateContainerSpec(testID, testSandboxID, testPid, config, sandboxConfig, imageConfig, nil)
+		spec, err := c.generateContainerSpec(testID, testSandboxID, testPid, config, sandboxConfig, criconfig.Runtime{}, imageConfig, nil)
 		require.NoError(t, err)
 		specCheck(t, testID, testSandboxID, testPid, spec)
 		assert.Equal(t, tty, spec.Process.Terminal)
@@ -292,7 +297,7 @@ func TestContainerSpecReadonlyRootfs(t *testing.T) {
 	c := newTestCRIService()
 	for _, readonly := range []bool{true, false} {
 		config.Linux.SecurityContext.ReadonlyRootfs = readonly
-		spec, err := c.generateContainerSpec(testID, testSandboxID, testPid, config, sandboxConfig, imageConfig, nil)
+		spec, err := c.generateContainerSpec(testID, testSandboxID, testPid, config, sandboxConfig, criconfig.Runtime{}, imageConfig, nil)
 		require.NoError(t, err)
 		specCheck(t, testID, testSandboxID, testPid, spec)
 		assert.Equal(t, readonly, spec.Root.Readonly)
@@ -316,7 +321,7 @@ func TestContainerSpecWithExtraMounts(t *testing.T) {
 		HostPath:      "test-host-path-extra",
 		Readonly:      true,
 	}
-	spec, err := c.generateContainerSpec(testID, testSandboxID, testPid, config, sandboxConfig, imageConfig, []*runtime.Mount{extraMount})
+	spec, err := c.generateContainerSpec(testID, testSandboxID, testPid, config, sandboxConfig, criconfig.Runtime{}, imageConfig, []*runtime.Mount{extraMount})
 	require.NoError(t, err)
 	specCheck(t, testID, testSandboxID, testPid, spec)
 	var mounts []runtimespec.Mount
@@ -370,7 +375,7 @@ func TestContainerAndSandboxPrivileged(t *testing.T) {
 		sandboxConfig.Linux.SecurityContext = &runtime.LinuxSandboxSecurityContext{
 			Privileged: test.sandboxPrivileged,
 		}
-		_, err := c.generateContainerSpec(testID, testSandboxID, testPid, config, sandboxConfig, imageConfig, nil)
+		_, err := c.generateContainerSpec(testID, testSandboxID, testPid, config, sandboxConfig, criconfig.Runtime{}, imageConfig, nil)
 		if test.expectError {
 			assert.Error(t, err)
 		} else {
@@ -488,6 +493,7 @@ func TestGenerateVolumeMounts(t *testing.T) {
 					assert.Equal(t,
 						filepath.Dir(m.HostPath),
 						filepath.Join(testContainerRootDir, "volumes"))
+					assert.True(t, m.SelinuxRelabel)
 					break
 				}
 			}
@@ -781,12 +787,70 @@ func TestPidNamespace(t *testing.T) {
 	} {
 		t.Logf("TestCase %q", desc)
 		config.Linux.SecurityContext.NamespaceOptions = &runtime.NamespaceOption{Pid: test.pidNS}
-		spec, err := c.generateContainerSpec(testID, testSandboxID, testPid, config, sandboxConfig, imageConfig, nil)
+		spec, err := c.generateContainerSpec(testID, testSandboxID, testPid, config, sandboxConfig, criconfig.Runtime{}, imageConfig, nil)
 		require.NoError(t, err)
 		assert.Contains(t, spec.Linux.Namespaces, test.expected)
 	}
 }
 
+func TestTmpfsMounts(t *testing.T) {
+	testID := "test-id"
+	testPid := uint32(1234)
+	testSandboxID := "sandbox-id"
+	c := newTestCRIService()
+	ociRuntime := criconfig.Runtime{TmpfsMounts: []string{"/run", "/tmp/"}}
+	for desc, test := range map[string]struct {
+		annotations map[string]string
+		mounts      []*runtime.Mount
+		expected    []string
+		expectErr   bool
+	}{
+		"tmpfs mounts should be added": {
+			expected: []string{"/run", "/tmp"},
+		},
+		"tmpfs mounts should be disabled by annotation": {
+			annotations: map[string]string{annotations.DisableTmpfsMounts: "true"},
+		},
+		"tmpfs mounts should be added if annotation is false": {
+			annotations: map[string]string{annotations.DisableTmpfsMounts: "false"},
+			expected:    []string{"/run", "/tmp"},
+		},
+		"invalid annotation should return error": {
+			annotations: map[string]string{annotations.DisableTmpfsMounts: "invalid"},
+			expectErr:   true,
+		},
+		"tmpfs mount should be skipped if path is mounted": {
+			mounts: []*runtime.Mount{
+				{
+					ContainerPath: "/tmp",
+					HostPath:      "/test-host-path",
+				},
+			},
+			expected: []string{"/run"},
+		},
+	} {
+		t.Logf("TestCase %q", desc)
+		config, sandboxConfig, imageConfig, _ := getCreateContainerTestData()
+		config.Annotations = test.annotations
+		config.Mounts = test.mounts
+		spec, err := c.generateContainerSpec(testID, testSandboxID, testPid, config, sandboxConfig, ociRuntime, imageConfig, nil)
+		if test.expectErr {
+			assert.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		var tmpfs []string
+		for _, m := range spec.Mounts {
+			if m.Type != "tmpfs" || (m.Destination != "/run" && m.Destination != "/tmp") {
+				continue
+			}
+			assert.Contains(t, m.Options, "tmpcopyup")
+			tmpfs = append(tmpfs, m.Destination)
+		}
+		assert.Equal(t, test.expected, tmpfs)
+	}
+}
+
 func TestDefaultRuntimeSpec(t *testing.T) {
 	spec, err := defaultRuntimeSpec("test-id")
 	assert.NoError(t, err)
@@ -796,12 +860,20 @@ func TestDefaultRuntimeSpec(t *testing.T) {
 }
 
 func TestGenerateSeccompSpecOpts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "seccomp-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	profilePath := filepath.Join(dir, "test-profile")
+	require.NoError(t, ioutil.WriteFile(profilePath, []byte(`{"defaultAction": "SCMP_ACT_ERRNO"}`), 0644))
+
 	for desc, test := range map[string]struct {
-		profile    string
-		privileged bool
-		disable    bool
-		specOpts   oci.SpecOpts
-		expectErr  bool
+		profile        string
+		privileged     bool
+		disable        bool
+		defaultProfile bool
+		applyDefault   bool
+		specOpts       oci.SpecOpts
+		expectErr      bool
 	}{
 		"should return error if seccomp is specified when seccomp is not supported": {
 			profile:   runtimeDefault,
@@ -836,15 +908,53 @@ func TestGenerateSeccompSpecOpts(t *testing.T) {
 		},
 		"should set specified profile when local profile is specified": {
 			profile:  profileNamePrefix + "test-profile",
-			specOpts: seccomp.WithProfile("test-profile"),
+			specOpts: customopts.WithSeccompProfile(nil),
 		},
 		"should return error if specified profile is invalid": {
 			profile:   "test-profile",
 			expectErr: true,
 		},
+		"should return error if local profile doesn't exist": {
+			profile:   profileNamePrefix + "not-exist",
+			expectErr: true,
+		},
+		"should return error if local profile escapes profile root": {
+			profile:   profileNamePrefix + "../test-profile",
+			expectErr: true,
+		},
+		"should set configured default seccomp when seccomp is runtime/default": {
+			profile:        runtimeDefault,
+			defaultProfile: true,
+			specOpts:       customopts.WithSeccompProfile(nil),
+		},
+		"should set default seccomp when seccomp is not specified if default is applied": {
+			profile:      "",
+			applyDefault: true,
+			specOpts:     seccomp.WithDefaultProfile(),
+		},
+		"should not return error if seccomp is not specified when seccomp is not supported if default is applied": {
+			profile:      "",
+			disable:      true,
+			applyDefault: true,
+		},
+		"should not set seccomp when privileged is true if default is applied": {
+			profile:      "",
+			privileged:   true,
+			applyDefault: true,
+		},
+		"should not set seccomp when seccomp is unconfined if default is applied": {
+			profile:      unconfinedProfile,
+			applyDefault: true,
+		},
 	} {
 		t.Logf("TestCase %q", desc)
-		specOpts, err := generateSeccompSpecOpts(test.profile, test.privileged, !test.disable)
+		var defaultProfile string
+		if test.defaultProfile {
+			defaultProfile = profilePath
+		}
+		profiles, err := newSeccompProfiles(dir, defaultProfile, test.applyDefault)
+		require.NoError(t, err)
+		specOpts, err := generateSeccompSpecOpts(test.profile, test.privileged, !test.disable, profiles)
 		assert.Equal(t,
 			reflect.ValueOf(test.specOpts).Pointer(),
 			reflect.ValueOf(specOpts).Pointer())
@@ -858,11 +968,13 @@ func TestGenerateSeccompSpecOpts(t *testing.T) {
 
 func TestGenerateApparmorSpecOpts(t *testing.T) {
 	for desc, test := range map[string]struct {
-		profile    string
-		privileged bool
-		disable    bool
-		specOpts   oci.SpecOpts
-		expectErr  bool
+		profile         string
+		privileged      bool
+		disable         bool
+		defaultTemplate bool
+		defaultLoaded   bool
+		specOpts        oci.SpecOpts
+		expectErr       bool
 	}{
 		"should return error if apparmor is specified when apparmor is not supported": {
 			profile:   runtimeDefault,
@@ -904,9 +1016,39 @@ func TestGenerateApparmorSpecOpts(t *testing.T) {
 			profile:   "test-profile",
 			expectErr: true,
 		},
+		"should return error if local profile is not loaded": {
+			profile:   profileNamePrefix + "not-loaded-profile",
+			expectErr: true,
+		},
+		"should set managed default apparmor when apparmor is not specified": {
+			profile:         "",
+			defaultTemplate: true,
+			defaultLoaded:   true,
+			specOpts:        apparmor.WithProfile(appArmorDefaultProfileName),
+		},
+		"should set managed default apparmor when apparmor is runtime/default": {
+			profile:         runtimeDefault,
+			defaultTemplate: true,
+			defaultLoaded:   true,
+			specOpts:        apparmor.WithProfile(appArmorDefaultProfileName),
+		},
+		"should set default apparmor when managed default apparmor is not loaded": {
+			profile:         runtimeDefault,
+			defaultTemplate: true,
+			specOpts:        apparmor.WithDefaultProfile(appArmorDefaultProfileName),
+		},
 	} {
 		t.Logf("TestCase %q", desc)
-		specOpts, err := generateApparmorSpecOpts(test.profile, test.privileged, !test.disable)
+		profiles := &apparmorProfiles{
+			loadedProfiles: func() (map[string]bool, error) {
+				return map[string]bool{"test-profile": true}, nil
+			},
+			defaultLoaded: atomic.NewBool(test.defaultLoaded),
+		}
+		if test.defaultTemplate {
+			profiles.defaultTemplate = "/test/template"
+		}
+		specOpts, err := generateApparmorSpecOpts(test.profile, test.privileged, !test.disable, profiles)
 		assert.Equal(t,
 			reflect.ValueOf(test.specOpts).Pointer(),
 			reflect.ValueOf(specOpts).Pointer())
@@ -28,9 +28,13 @@ func (c *criService) Exec(ctx context.Context, r *runtime.ExecRequest) (*runtime
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to find container %q in store", r.GetContainerId())
 	}
-	state := cntr.Status.Get().State()
-	if state != runtime.ContainerState_CONTAINER_RUNNING {
+	status := cntr.Status.Get()
+	if state := status.State(); state != runtime.ContainerState_CONTAINER_RUNNING {
 		return nil, errors.Errorf("container is in %s state", criContainerStateToString(state))
 	}
+	// Exec processes can't run in the frozen cgroup of a paused container.
+	if status.Paused {
+		return nil, errors.New("container is paused")
+	}
 	return c.streamServer.GetExec(r)
 }
@@ -85,10 +85,14 @@ func (c *criService) execInContainer(ctx context.Context, id string, opts execOp
 	}
 	id = cntr.ID
 
-	state := cntr.Status.Get().State()
-	if state != runtime.ContainerState_CONTAINER_RUNNING {
+	status := cntr.Status.Get()
+	if state := status.State(); state != runtime.ContainerState_CONTAINER_RUNNING {
 		return nil, errors.Errorf("container is in %s state", criContainerStateToString(state))
 	}
+	// Exec processes can't run in the frozen cgroup of a paused container.
+	if status.Paused {
+		return nil, errors.New("container is paused")
+	}
 
 	container := cntr.Container
 	spec, err := container.Spec(ctx)
@@ -0,0 +1,67 @@
+/*
+Copyright 2018 The Containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
+
+	api "github.com/containerd/cri/pkg/api/v1"
+	containerstore "github.com/containerd/cri/pkg/store/container"
+)
+
+// PauseContainer freezes all processes of a running container.
+func (c *criService) PauseContainer(ctx context.Context, r *api.PauseContainerRequest) (*api.PauseContainerResponse, error) {
+	container, err := c.containerStore.Get(r.GetContainerId())
+	if err != nil {
+		return nil, errors.Wrapf(err, "an error occurred when try to find container %q", r.GetContainerId())
+	}
+	if err := c.pauseContainer(ctx, container); err != nil {
+		return nil, err
+	}
+	return &api.PauseContainerResponse{}, nil
+}
+
+// pauseContainer pauses the container if it is not paused yet. The paused
+// state is checkpointed, so that it survives containerd restart.
+func (c *criService) pauseContainer(ctx context.Context, container containerstore.Container) error {
+	id := container.ID
+	return container.Status.UpdateSync(func(status containerstore.Status) (containerstore.Status, error) {
+		if status.State() != runtime.ContainerState_CONTAINER_RUNNING {
+			return status, errors.Errorf("container %q is in %s state", id, criContainerStateToString(status.State()))
+		}
+		if status.Removing {
+			return status, errors.Errorf("container %q is in removing state", id)
+		}
+		if status.Paused {
+			return status, nil
+		}
+		task, err := container.Container.Task(ctx, nil)
+		if err != nil {
+			return status, errors.Wrapf(err, "failed to get task for container %q", id)
+		}
+		if err := task.Pause(ctx); err != nil {
+			return status, errors.Wrapf(err, "failed to pause container %q", id)
+		}
+		status.Paused = true
+		status.PausedAt = time.Now().UnixNano()
+		return status, nil
+	})
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2018 The Containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
+
+	api "github.com/containerd/cri/pkg/api/v1"
+	customopts "github.com/containerd/cri/pkg/containerd/opts"
+	ctrdutil "github.com/containerd/cri/pkg/containerd/util"
+	containerstore "github.com/containerd/cri/pkg/store/container"
+	imagestore "github.com/containerd/cri/pkg/store/image"
+	sandboxstore "github.com/containerd/cri/pkg/store/sandbox"
+)
+
+// RestoreContainer restores a container from a checkpoint archive into a pod
+// sandbox. The container is created with the checkpointed container config,
+// its writable layer is restored from the archive, and it is started from the
+// checkpointed CRIU image.
+func (c *criService) RestoreContainer(ctx context.Context, r *api.RestoreContainerRequest) (_ *api.RestoreContainerResponse, retErr error) {
+	path := r.GetPath()
+	if !filepath.IsAbs(path) {
+		return nil, errors.Errorf("path %q is not an absolute path", path)
+	}
+	sandbox, err := c.sandboxStore.Get(r.GetPodSandboxId())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find sandbox id %q", r.GetPodSandboxId())
+	}
+	if sandbox.Status.Get().State != sandboxstore.StateReady {
+		return nil, errors.Errorf("sandbox container %q is not running", sandbox.ID)
+	}
+	ociRuntime, err := c.getSandboxRuntimeConfig(ctx, sandbox)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get runtime of sandbox %q", sandbox.ID)
+	}
+
+	// Hold a lease, so that the checkpoint content is not garbage collected
+	// before the container is restored.
+	ctx, done, err := c.client.WithLease(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create lease")
+	}
+	defer done()
+
+	meta, err := readCheckpointArchive(ctx, c.client.ContentStore(), path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read checkpoint archive %q", path)
+	}
+	if meta.RuntimeType != ociRuntime.Type {
+		return nil, errors.Errorf("checkpoint of runtime type %q can't be restored on runtime type %q",
+			meta.RuntimeType, ociRuntime.Type)
+	}
+
+	// Create the container from the checkpointed image id, the image name may
+	// have been updated to another image.
+	if _, err := c.getCheckpointImage(ctx, meta, c.getSandboxSnapshotter(sandbox)); err != nil {
+		return nil, err
+	}
+	config := *meta.Config
+	config.Image = &runtime.ImageSpec{Image: meta.ImageRef}
+	resp, err := c.CreateContainer(ctx, &runtime.CreateContainerRequest{
+		PodSandboxId:  sandbox.ID,
+		Config:        &config,
+		SandboxConfig: sandbox.Config,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create container")
+	}
+	id := resp.GetContainerId()
+	defer func() {
+		if retErr != nil {
+			deferCtx, deferCancel := ctrdutil.DeferContext()
+			defer deferCancel()
+			if _, err := c.RemoveContainer(deferCtx, &runtime.RemoveContainerRequest{ContainerId: id}); err != nil {
+				logrus.WithError(err).Errorf("Failed to remove container %q after failed restore", id)
+			}
+		}
+	}()
+	cntr, err := c.containerStore.Get(id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find container %q", id)
+	}
+	if err := c.restoreRWLayer(ctx, cntr, meta.RWLayer); err != nil {
+		return nil, errors.Wrapf(err, "failed to restore writable layer of container %q", id)
+	}
+
+	var startErr error
+	if err := cntr.Status.UpdateSync(func(status containerstore.Status) (containerstore.Status, error) {
+		startErr = c.startContainer(ctx, cntr, &status, customopts.WithCheckpoint(meta.CRIU))
+		return status, nil
+	}); startErr != nil {
+		return nil, errors.Wrapf(startErr, "failed to start container %q from checkpoint", id)
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to update container %q metadata", id)
+	}
+	return &api.RestoreContainerResponse{ContainerId: id}, nil
+}
+
+// readCheckpointArchive reads the checkpoint metadata from the archive, and
+// writes the checkpoint content into the content store.
+func readCheckpointArchive(ctx context.Context, cs content.Ingester, path string) (*checkpointMetadata, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open file")
+	}
+	defer f.Close()
+	tr := tar.NewReader(f)
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read archive")
+	}
+	if hdr.Name != checkpointMetadataFile {
+		return nil, errors.Errorf("unexpected file %q, %q should be the first file", hdr.Name, checkpointMetadataFile)
+	}
+	data, err := ioutil.ReadAll(tr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %q", checkpointMetadataFile)
+	}
+	var meta checkpointMetadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal %q", checkpointMetadataFile)
+	}
+	if meta.Version != checkpointVersion {
+		return nil, errors.Errorf("unsupported checkpoint version %q", meta.Version)
+	}
+	if meta.Config == nil {
+		return nil, errors.New("container config not found in checkpoint")
+	}
+	blobs := map[string]ocispec.Descriptor{
+		checkpointCRIUFile:    meta.CRIU,
+		checkpointRWLayerFile: meta.RWLayer,
+	}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read archive")
+		}
+		desc, ok := blobs[hdr.Name]
+		if !ok {
+			logrus.Debugf("Ignore unknown file %q in checkpoint archive", hdr.Name)
+			continue
+		}
+		if err := content.WriteBlob(ctx, cs, "restore-"+desc.Digest.String(),
+			tr, desc.Size, desc.Digest); err != nil {
+			return nil, errors.Wrapf(err, "failed to write %q into content store", hdr.Name)
+		}
+		delete(blobs, hdr.Name)
+	}
+	for _, name := range []string{checkpointCRIUFile, checkpointRWLayerFile} {
+		if _, ok := blobs[name]; ok {
+			return nil, errors.Errorf("%q not found in archive", name)
+		}
+	}
+	return &meta, nil
+}
+
+// getCheckpointImage returns the image of the checkpointed container. The
+// image is pulled with the image name in the container config if it doesn't
+// exist, and it must be the same image the container was checkpointed with.
+func (c *criService) getCheckpointImage(ctx context.Context, meta *checkpointMetadata, snapshotter string) (*imagestore.Image, error) {
+	image, err := c.localResolve(ctx, meta.ImageRef)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to resolve image %q", meta.ImageRef)
+	}
+	if image != nil && !image.Broken {
+		if err := c.ensureImageUnpacked(ctx, image, snapshotter); err != nil {
+			return nil, err
+		}
+		return image, nil
+	}
+	ref := meta.Config.GetImage().GetImage()
+	image, err = c.ensureImageExists(ctx, ref, snapshotter)
+	if err != nil {
+		return nil, err
+	}
+	if image.ID != meta.ImageRef {
+		return nil, errors.Errorf("image %q is %q, not the checkpointed image %q", ref, image.ID, meta.ImageRef)
+	}
+	return image, nil
+}
+
+// restoreRWLayer applies the checkpointed writable layer diff onto the
+// snapshot of the container.
+func (c *criService) restoreRWLayer(ctx context.Context, cntr containerstore.Container, desc ocispec.Descriptor) error {
+	info, err := cntr.Container.Info(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get container info")
+	}
+	mounts, err := c.client.SnapshotService(info.Snapshotter).Mounts(ctx, info.SnapshotKey)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get mounts of snapshot %q", info.SnapshotKey)
+	}
+	if _, err := c.client.DiffService().Apply(ctx, desc, mounts); err != nil {
+		if errdefs.IsNotImplemented(err) {
+			return errors.Wrapf(err, "unsupported writable layer media type %q", desc.MediaType)
+		}
+		return errors.Wrap(err, "failed to apply writable layer diff")
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2018 The Containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"github.com/containerd/containerd/errdefs"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+
+	api "github.com/containerd/cri/pkg/api/v1"
+	containerstore "github.com/containerd/cri/pkg/store/container"
+)
+
+// ResumeContainer thaws a paused container.
+func (c *criService) ResumeContainer(ctx context.Context, r *api.ResumeContainerRequest) (*api.ResumeContainerResponse, error) {
+	container, err := c.containerStore.Get(r.GetContainerId())
+	if err != nil {
+		return nil, errors.Wrapf(err, "an error occurred when try to find container %q", r.GetContainerId())
+	}
+	if err := c.resumeContainer(ctx, container); err != nil {
+		return nil, err
+	}
+	return &api.ResumeContainerResponse{}, nil
+}
+
+// resumeContainer resumes the container if it is paused.
+func (c *criService) resumeContainer(ctx context.Context, container containerstore.Container) error {
+	id := container.ID
+	return container.Status.UpdateSync(func(status containerstore.Status) (containerstore.Status, error) {
+		if !status.Paused {
+			return status, nil
+		}
+		task, err := container.Container.Task(ctx, nil)
+		if err != nil {
+			if !errdefs.IsNotFound(err) {
+				return status, errors.Wrapf(err, "failed to get task for container %q", id)
+			}
+			// The task is gone, the exit event will update the container status.
+		} else if err := task.Resume(ctx); err != nil {
+			return status, errors.Wrapf(err, "failed to resume container %q", id)
+		}
+		status.Paused = false
+		status.PausedAt = 0
+		return status, nil
+	})
+}
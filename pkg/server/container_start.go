@@ -57,10 +57,12 @@ func (c *criService) StartContainer(ctx context.Context, r *runtime.StartContain
 }
 
 // startContainer actually starts the container. The function needs to be run in one transaction. Any updates
-// to the status passed in will be applied no matter the function returns error or not.
+// to the status passed in will be applied no matter the function returns error or not. The task options are
+// used to create the containerd task, e.g. to restore the container from a checkpoint.
 func (c *criService) startContainer(ctx context.Context,
 	cntr containerstore.Container,
-	status *containerstore.Status) (retErr error) {
+	status *containerstore.Status,
+	taskOpts ...containerd.NewTaskOpts) (retErr error) {
 	id := cntr.ID
 	meta := cntr.Metadata
 	container := cntr.Container
@@ -116,7 +118,7 @@ func (c *criService) startContainer(ctx context.Context,
 		return cntr.IO, nil
 	}
 
-	task, err := container.NewTask(ctx, ioCreation)
+	task, err := container.NewTask(ctx, ioCreation, taskOpts...)
 	if err != nil {
 		return errors.Wrap(err, "failed to create containerd task")
 	}
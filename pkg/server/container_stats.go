@@ -17,10 +17,11 @@ limitations under the License.
 package server
 
 import (
-	tasks "github.com/containerd/containerd/api/services/tasks/v1"
 	"github.com/pkg/errors"
 	"golang.org/x/net/context"
 	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
+
+	containerstore "github.com/containerd/cri/pkg/store/container"
 )
 
 // ContainerStats returns stats of the container. If the container does not
@@ -30,18 +31,14 @@ func (c *criService) ContainerStats(ctx context.Context, in *runtime.ContainerSt
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to find container")
 	}
-	request := &tasks.MetricsRequest{Filters: []string{"id==" + cntr.ID}}
-	resp, err := c.client.TaskService().Metrics(ctx, request)
+	stats, err := c.getContainersStats(ctx, []containerstore.Container{cntr})
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to fetch metrics for task")
-	}
-	if len(resp.Metrics) != 1 {
-		return nil, errors.Errorf("unexpected metrics response: %+v", resp.Metrics)
+		return nil, errors.Wrap(err, "failed to get container stats")
 	}
-
-	cs, err := c.getContainerMetrics(cntr.Metadata, resp.Metrics[0])
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to decode container metrics")
+	s, ok := stats[cntr.ID]
+	if !ok {
+		return nil, errors.New("container stats not found")
 	}
+	cs := c.getContainerMetrics(cntr.Metadata, &s)
 	return &runtime.ContainerStatsResponse{Stats: cs}, nil
 }
@@ -0,0 +1,150 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
+
+	api "github.com/containerd/cri/pkg/api/v1"
+	sandboxstore "github.com/containerd/cri/pkg/store/sandbox"
+)
+
+// netDevFormat is the format of the network device stats file of a process.
+const netDevFormat = "/proc/%v/net/dev"
+
+// ContainerStatsDetailed returns detailed stats of all containers matching the filter.
+func (c *criService) ContainerStatsDetailed(ctx context.Context, r *api.ContainerStatsDetailedRequest) (*api.ContainerStatsDetailedResponse, error) {
+	// Always pass in a filter, so that all containers are returned
+	// when no filter is specified.
+	filter := &runtime.ContainerStatsFilter{
+		Id:            r.GetFilter().GetId(),
+		PodSandboxId:  r.GetFilter().GetPodSandboxId(),
+		LabelSelector: r.GetFilter().GetLabelSelector(),
+	}
+	containers := c.filterContainersForStats(filter)
+	stats, err := c.getContainersStats(ctx, containers)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get containers stats")
+	}
+	resp := &api.ContainerStatsDetailedResponse{}
+	// Network stats are per sandbox, only collect them once for each sandbox.
+	networkMap := make(map[string][]*api.NetworkInterfaceStats)
+	for _, cntr := range containers {
+		cs := &api.ContainerStatsDetailed{
+			Id:           cntr.ID,
+			PodSandboxId: cntr.SandboxID,
+		}
+		if s, ok := stats[cntr.ID]; ok {
+			cs.Timestamp = s.Timestamp
+			cs.Metrics = s.Metrics
+			cs.CpuUsageNanoCores = s.UsageNanoCores
+		}
+		network, ok := networkMap[cntr.SandboxID]
+		if !ok {
+			sb, err := c.sandboxStore.Get(cntr.SandboxID)
+			if err == nil {
+				network, err = getSandboxNetworkStats(sb)
+				if err != nil {
+					// Network stats are best effort, the sandbox may be
+					// stopped in the middle.
+					logrus.WithError(err).Debugf("Failed to get network stats for sandbox %q", sb.ID)
+				}
+			}
+			networkMap[cntr.SandboxID] = network
+		}
+		cs.Network = network
+		resp.Stats = append(resp.Stats, cs)
+	}
+	return resp, nil
+}
+
+// getSandboxNetworkStats returns the stats of network interfaces in the
+// sandbox network namespace. It returns nil if the sandbox is not ready
+// or uses host network.
+func getSandboxNetworkStats(sb sandboxstore.Sandbox) ([]*api.NetworkInterfaceStats, error) {
+	if sb.Config.GetLinux().GetSecurityContext().GetNamespaceOptions().GetNetwork() == runtime.NamespaceMode_NODE {
+		return nil, nil
+	}
+	status := sb.Status.Get()
+	if status.State != sandboxstore.StateReady {
+		return nil, nil
+	}
+	data, err := ioutil.ReadFile(fmt.Sprintf(netDevFormat, status.Pid))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read network device stats")
+	}
+	return parseNetDev(data, time.Now().UnixNano())
+}
+
+// parseNetDev parses the content of /proc/<pid>/net/dev. The loopback
+// interface is skipped.
+func parseNetDev(data []byte, timestamp int64) ([]*api.NetworkInterfaceStats, error) {
+	var stats []*api.NetworkInterfaceStats
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		parts := strings.SplitN(line, ":", 2)
+		// Skip the header lines.
+		if len(parts) != 2 {
+			continue
+		}
+		name := strings.TrimSpace(parts[0])
+		if name == "lo" {
+			continue
+		}
+		fields := strings.Fields(parts[1])
+		// The receive and transmit sections have 8 fields each.
+		if len(fields) != 16 {
+			return nil, errors.Errorf("unexpected network device stats %q", line)
+		}
+		var values [16]uint64
+		for i, f := range fields {
+			v, err := strconv.ParseUint(f, 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse network device stats %q", line)
+			}
+			values[i] = v
+		}
+		stats = append(stats, &api.NetworkInterfaceStats{
+			Name:      name,
+			Timestamp: timestamp,
+			RxBytes:   values[0],
+			RxPackets: values[1],
+			RxErrors:  values[2],
+			RxDropped: values[3],
+			TxBytes:   values[8],
+			TxPackets: values[9],
+			TxErrors:  values[10],
+			TxDropped: values[11],
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to scan network device stats")
+	}
+	return stats, nil
+}
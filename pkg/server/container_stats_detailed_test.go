@@ -0,0 +1,78 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/containerd/cri/pkg/api/v1"
+)
+
+func TestParseNetDev(t *testing.T) {
+	const timestamp = int64(1234)
+	for desc, test := range map[string]struct {
+		data        string
+		expectErr   bool
+		expectStats []*api.NetworkInterfaceStats
+	}{
+		"should parse interfaces and skip loopback": {
+			data: `Inter-|   Receive                                                |  Transmit
+ face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+    lo:     100       2    0    0    0     0          0         0      100       2    0    0    0     0       0          0
+  eth0:    1000      10    1    2    0     0          0         0     2000      20    3    4    0     0       0          0
+`,
+			expectStats: []*api.NetworkInterfaceStats{
+				{
+					Name:      "eth0",
+					Timestamp: timestamp,
+					RxBytes:   1000,
+					RxPackets: 10,
+					RxErrors:  1,
+					RxDropped: 2,
+					TxBytes:   2000,
+					TxPackets: 20,
+					TxErrors:  3,
+					TxDropped: 4,
+				},
+			},
+		},
+		"should return nil if there is only header": {
+			data: `Inter-|   Receive                                                |  Transmit
+ face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+`,
+		},
+		"should return error if field number is unexpected": {
+			data:      "  eth0:    1000      10    1    2\n",
+			expectErr: true,
+		},
+		"should return error if field is not a number": {
+			data:      "  eth0:    1000      10    1    2    0     0          0         0     2000      20    3    4    0     0       0          x\n",
+			expectErr: true,
+		},
+	} {
+		t.Logf("TestCase %q", desc)
+		stats, err := parseNetDev([]byte(test.data), timestamp)
+		if test.expectErr {
+			assert.Error(t, err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, test.expectStats, stats)
+	}
+}
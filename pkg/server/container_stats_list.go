@@ -17,15 +17,13 @@ limitations under the License.
 package server
 
 import (
-	"github.com/containerd/cgroups"
 	tasks "github.com/containerd/containerd/api/services/tasks/v1"
-	"github.com/containerd/containerd/api/types"
-	"github.com/containerd/typeurl"
 	"github.com/pkg/errors"
 	"golang.org/x/net/context"
 	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
 
 	containerstore "github.com/containerd/cri/pkg/store/container"
+	statsstore "github.com/containerd/cri/pkg/store/stats"
 )
 
 // ListContainerStats returns stats of all running containers.
@@ -33,47 +31,70 @@ func (c *criService) ListContainerStats(
 	ctx context.Context,
 	in *runtime.ListContainerStatsRequest,
 ) (*runtime.ListContainerStatsResponse, error) {
-	request, containers, err := c.buildTaskMetricsRequest(in)
+	containers := c.filterContainersForStats(in.GetFilter())
+	stats, err := c.getContainersStats(ctx, containers)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to build metrics request")
+		return nil, errors.Wrap(err, "failed to get containers stats")
 	}
-	resp, err := c.client.TaskService().Metrics(ctx, &request)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to fetch metrics for tasks")
-	}
-	criStats, err := c.toCRIContainerStats(resp.Metrics, containers)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to convert to cri containerd stats format")
+	criStats := new(runtime.ListContainerStatsResponse)
+	for _, cntr := range containers {
+		var cs *statsstore.ContainerStats
+		if s, ok := stats[cntr.ID]; ok {
+			cs = &s
+		}
+		criStats.Stats = append(criStats.Stats, c.getContainerMetrics(cntr.Metadata, cs))
 	}
 	return criStats, nil
 }
 
-func (c *criService) toCRIContainerStats(
-	stats []*types.Metric,
+// getContainersStats returns cpu/memory stats of the containers. Stats are
+// served from the stats store if container stats are cached, and fetched from
+// containerd if they are not cached yet, e.g. the container is just started.
+func (c *criService) getContainersStats(
+	ctx context.Context,
 	containers []containerstore.Container,
-) (*runtime.ListContainerStatsResponse, error) {
-	statsMap := make(map[string]*types.Metric)
-	for _, stat := range stats {
-		statsMap[stat.ID] = stat
-	}
-	containerStats := new(runtime.ListContainerStatsResponse)
+) (map[string]statsstore.ContainerStats, error) {
+	stats := make(map[string]statsstore.ContainerStats)
+	var req tasks.MetricsRequest
 	for _, cntr := range containers {
-		cs, err := c.getContainerMetrics(cntr.Metadata, statsMap[cntr.ID])
+		if c.config.ContainerStatsCollectPeriod > 0 {
+			if s, err := c.statsStore.Get(cntr.ID); err == nil {
+				stats[cntr.ID] = s
+				continue
+			}
+			// Only running container has metrics, do not query containerd
+			// for stopped containers every time.
+			if cntr.Status.Get().State() != runtime.ContainerState_CONTAINER_RUNNING {
+				continue
+			}
+		}
+		req.Filters = append(req.Filters, "id=="+cntr.ID)
+	}
+	// Do not send request without filter, which returns metrics of all tasks.
+	if len(req.Filters) == 0 {
+		return stats, nil
+	}
+	resp, err := c.client.TaskService().Metrics(ctx, &req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch metrics for tasks")
+	}
+	for _, m := range resp.Metrics {
+		s, err := toContainerStats(m)
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to decode container metrics for %q", cntr.ID)
+			return nil, errors.Wrapf(err, "failed to decode container metrics for %q", m.ID)
 		}
-		containerStats.Stats = append(containerStats.Stats, cs)
+		stats[m.ID] = s
 	}
-	return containerStats, nil
+	return stats, nil
 }
 
 func (c *criService) getContainerMetrics(
 	meta containerstore.Metadata,
-	stats *types.Metric,
-) (*runtime.ContainerStats, error) {
+	stats *statsstore.ContainerStats,
+) *runtime.ContainerStats {
 	var cs runtime.ContainerStats
 	var usedBytes, inodesUsed uint64
-	sn, err := c.snapshotStore.Get(meta.ID)
+	sn, err := c.snapshotStore.Get(meta.Snapshotter, meta.ID)
 	// If snapshotstore doesn't have cached snapshot information
 	// set WritableLayer usage to zero
 	if err == nil {
@@ -83,7 +104,7 @@ func (c *criService) getContainerMetrics(
 	cs.WritableLayer = &runtime.FilesystemUsage{
 		Timestamp: sn.Timestamp,
 		FsId: &runtime.FilesystemIdentifier{
-			Mountpoint: c.imageFSPath,
+			Mountpoint: c.getImageFSPath(meta.Snapshotter),
 		},
 		UsedBytes:  &runtime.UInt64Value{Value: usedBytes},
 		InodesUsed: &runtime.UInt64Value{Value: inodesUsed},
@@ -96,26 +117,22 @@ func (c *criService) getContainerMetrics(
 	}
 
 	if stats != nil {
-		s, err := typeurl.UnmarshalAny(stats.Data)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to extract container metrics")
-		}
-		metrics := s.(*cgroups.Metrics)
+		metrics := stats.Metrics
 		if metrics.CPU != nil && metrics.CPU.Usage != nil {
 			cs.Cpu = &runtime.CpuUsage{
-				Timestamp:            stats.Timestamp.UnixNano(),
+				Timestamp:            stats.Timestamp,
 				UsageCoreNanoSeconds: &runtime.UInt64Value{Value: metrics.CPU.Usage.Total},
 			}
 		}
 		if metrics.Memory != nil && metrics.Memory.Usage != nil {
 			cs.Memory = &runtime.MemoryUsage{
-				Timestamp:       stats.Timestamp.UnixNano(),
+				Timestamp:       stats.Timestamp,
 				WorkingSetBytes: &runtime.UInt64Value{Value: metrics.Memory.Usage.Usage},
 			}
 		}
 	}
 
-	return &cs, nil
+	return &cs
 }
 
 func (c *criService) normalizeContainerStatsFilter(filter *runtime.ContainerStatsFilter) {
@@ -127,32 +144,27 @@ func (c *criService) normalizeContainerStatsFilter(filter *runtime.ContainerStat
 	}
 }
 
-// buildTaskMetricsRequest constructs a tasks.MetricsRequest based on
-// the information in the stats request and the containerStore
-func (c *criService) buildTaskMetricsRequest(
-	r *runtime.ListContainerStatsRequest,
-) (tasks.MetricsRequest, []containerstore.Container, error) {
-	var req tasks.MetricsRequest
-	if r.GetFilter() == nil {
-		return req, nil, nil
+// filterContainersForStats returns containers matching the stats filter.
+func (c *criService) filterContainersForStats(filter *runtime.ContainerStatsFilter) []containerstore.Container {
+	if filter == nil {
+		return nil
 	}
-	c.normalizeContainerStatsFilter(r.GetFilter())
+	c.normalizeContainerStatsFilter(filter)
 	var containers []containerstore.Container
 	for _, cntr := range c.containerStore.List() {
-		if r.GetFilter().GetId() != "" && cntr.ID != r.GetFilter().GetId() {
+		if filter.GetId() != "" && cntr.ID != filter.GetId() {
 			continue
 		}
-		if r.GetFilter().GetPodSandboxId() != "" && cntr.SandboxID != r.GetFilter().GetPodSandboxId() {
+		if filter.GetPodSandboxId() != "" && cntr.SandboxID != filter.GetPodSandboxId() {
 			continue
 		}
-		if r.GetFilter().GetLabelSelector() != nil &&
-			!matchLabelSelector(r.GetFilter().GetLabelSelector(), cntr.Config.GetLabels()) {
+		if filter.GetLabelSelector() != nil &&
+			!matchLabelSelector(filter.GetLabelSelector(), cntr.Config.GetLabels()) {
 			continue
 		}
 		containers = append(containers, cntr)
-		req.Filters = append(req.Filters, "id=="+cntr.ID)
 	}
-	return req, containers, nil
+	return containers
 }
 
 func matchLabelSelector(selector, labels map[string]string) bool {
@@ -0,0 +1,93 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/containerd/cgroups"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
+
+	containerstore "github.com/containerd/cri/pkg/store/container"
+	statsstore "github.com/containerd/cri/pkg/store/stats"
+)
+
+func TestListContainerStatsFromCache(t *testing.T) {
+	c := newTestCRIService()
+	c.config.ContainerStatsCollectPeriod = 10
+	now := time.Now().UnixNano()
+	for _, cntr := range []containerForTest{
+		{
+			metadata: containerstore.Metadata{
+				ID:        "c-running",
+				SandboxID: "s-1",
+				Config:    &runtime.ContainerConfig{Labels: map[string]string{"a": "b"}},
+			},
+			status: containerstore.Status{CreatedAt: now, StartedAt: now},
+		},
+		{
+			metadata: containerstore.Metadata{
+				ID:        "c-exited",
+				SandboxID: "s-1",
+				Config:    &runtime.ContainerConfig{Labels: map[string]string{"a": "b"}},
+			},
+			status: containerstore.Status{CreatedAt: now, StartedAt: now, FinishedAt: now},
+		},
+	} {
+		container, err := cntr.toContainer()
+		require.NoError(t, err)
+		require.NoError(t, c.containerStore.Add(container))
+	}
+	c.statsStore.Add(statsstore.ContainerStats{
+		ID:        "c-running",
+		Timestamp: 1234,
+		Metrics: &cgroups.Metrics{
+			CPU:    &cgroups.CPUStat{Usage: &cgroups.CPUUsage{Total: 100}},
+			Memory: &cgroups.MemoryStat{Usage: &cgroups.MemoryEntry{Usage: 200}},
+		},
+	})
+
+	resp, err := c.ListContainerStats(context.Background(), &runtime.ListContainerStatsRequest{
+		Filter: &runtime.ContainerStatsFilter{LabelSelector: map[string]string{"a": "b"}},
+	})
+	require.NoError(t, err)
+	stats := map[string]*runtime.ContainerStats{}
+	for _, s := range resp.GetStats() {
+		stats[s.GetAttributes().GetId()] = s
+	}
+	require.Len(t, stats, 2)
+
+	t.Logf("should serve stats of running container from cache")
+	running := stats["c-running"]
+	assert.Equal(t, &runtime.CpuUsage{
+		Timestamp:            1234,
+		UsageCoreNanoSeconds: &runtime.UInt64Value{Value: 100},
+	}, running.GetCpu())
+	assert.Equal(t, &runtime.MemoryUsage{
+		Timestamp:       1234,
+		WorkingSetBytes: &runtime.UInt64Value{Value: 200},
+	}, running.GetMemory())
+
+	t.Logf("should not return cpu/memory stats for exited container")
+	exited := stats["c-exited"]
+	assert.Nil(t, exited.GetCpu())
+	assert.Nil(t, exited.GetMemory())
+}
@@ -104,11 +104,14 @@ type containerInfo struct {
 	SandboxID   string                   `json:"sandboxID"`
 	Pid         uint32                   `json:"pid"`
 	Removing    bool                     `json:"removing"`
+	Paused      bool                     `json:"paused"`
+	PausedAt    int64                    `json:"pausedAt"`
 	SnapshotKey string                   `json:"snapshotKey"`
 	Snapshotter string                   `json:"snapshotter"`
 	Runtime     *criconfig.Runtime       `json:"runtime"`
 	Config      *runtime.ContainerConfig `json:"config"`
 	RuntimeSpec *runtimespec.Spec        `json:"runtimeSpec"`
+	Hooks       []string                 `json:"hooks,omitempty"`
 }
 
 // toCRIContainerInfo converts internal container object information to CRI container status response info map.
@@ -126,7 +129,10 @@ func toCRIContainerInfo(ctx context.Context, container containerstore.Container,
 		SandboxID: container.SandboxID,
 		Pid:       status.Pid,
 		Removing:  status.Removing,
+		Paused:    status.Paused,
+		PausedAt:  status.PausedAt,
 		Config:    meta.Config,
+		Hooks:     meta.Hooks,
 	}
 
 	var err error
@@ -63,6 +63,11 @@ func (c *criService) stopContainer(ctx context.Context, container containerstore
 		return nil
 	}
 
+	// Signals are not delivered to a paused container, resume it first.
+	if err := c.resumeContainer(ctx, container); err != nil {
+		return errors.Wrapf(err, "failed to resume paused container %q", id)
+	}
+
 	if timeout > 0 {
 		stopSignal := unix.SIGTERM
 		image, err := c.imageStore.Get(container.ImageRef)
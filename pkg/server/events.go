@@ -259,6 +259,7 @@ func handleContainerExit(ctx context.Context, e *eventtypes.TaskExit, cntr conta
 			return status, nil
 		}
 		status.Pid = 0
+		status.Paused = false
 		status.FinishedAt = e.ExitedAt.UnixNano()
 		status.ExitCode = int32(e.ExitStatus)
 		return status, nil
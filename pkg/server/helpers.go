@@ -21,12 +21,15 @@ import (
 	"fmt"
 	"path"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/containers"
 	"github.com/containerd/containerd/content"
+	containerdimages "github.com/containerd/containerd/images"
 	"github.com/containerd/containerd/linux/runctypes"
 	"github.com/containerd/typeurl"
 	"github.com/docker/distribution/reference"
@@ -38,12 +41,16 @@ import (
 	"github.com/opencontainers/selinux/go-selinux"
 	"github.com/opencontainers/selinux/go-selinux/label"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
+	"golang.org/x/sys/unix"
 	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
 
 	criconfig "github.com/containerd/cri/pkg/config"
+	ctrdutil "github.com/containerd/cri/pkg/containerd/util"
 	"github.com/containerd/cri/pkg/store"
 	imagestore "github.com/containerd/cri/pkg/store/image"
+	sandboxstore "github.com/containerd/cri/pkg/store/sandbox"
 	"github.com/containerd/cri/pkg/util"
 )
 
@@ -59,6 +66,9 @@ const (
 	errorExitReason = "Error"
 	// oomExitReason is the exit reason when process in container is oom killed.
 	oomExitReason = "OOMKilled"
+	// writableLayerLimitExceededReason is the exit reason when container is
+	// killed because its writable layer exceeds the limit.
+	writableLayerLimitExceededReason = "WritableLayerLimitExceeded"
 )
 
 const (
@@ -89,12 +99,16 @@ const (
 	utsNSFormat = "/proc/%v/ns/uts"
 	// pidNSFormat is the format of pid namespace of a process.
 	pidNSFormat = "/proc/%v/ns/pid"
+	// userNSFormat is the format of user namespace of a process.
+	userNSFormat = "/proc/%v/ns/user"
 	// devShm is the default path of /dev/shm.
 	devShm = "/dev/shm"
 	// etcHosts is the default path of /etc/hosts file.
 	etcHosts = "/etc/hosts"
 	// resolvConfPath is the abs path of resolv.conf on host or container.
 	resolvConfPath = "/etc/resolv.conf"
+	// overlayfsSnapshotter is the name of the overlayfs snapshotter.
+	overlayfsSnapshotter = "overlayfs"
 )
 
 const (
@@ -110,6 +124,12 @@ const (
 	sandboxMetadataExtension = criContainerdPrefix + ".sandbox.metadata"
 	// containerMetadataExtension is an extension name that identify metadata of container in CreateContainerRequest
 	containerMetadataExtension = criContainerdPrefix + ".container.metadata"
+	// imagePulledLabel is a label key of the image id reference in containerd,
+	// indicating the last time when the image is pulled.
+	imagePulledLabel = criContainerdPrefix + ".image.pulled"
+	// imageLastUsedLabel is a label key of the image id reference in containerd,
+	// indicating the last time when the image is used by container or sandbox.
+	imageLastUsedLabel = criContainerdPrefix + ".image.last-used"
 )
 
 const (
@@ -155,6 +175,35 @@ func getCgroupsPath(cgroupsParent, id string, systemdCgroup bool) string {
 	return filepath.Join(cgroupsParent, id)
 }
 
+// getPodCgroupsPath generates the pod level cgroups path from the sandbox
+// cgroups parent. It returns the path in cgroupfs format, so that the cgroup
+// could be loaded directly.
+func getPodCgroupsPath(cgroupsParent string, systemdCgroup bool) (string, error) {
+	if !systemdCgroup {
+		return cgroupsParent, nil
+	}
+	// Only the last slice is used in getCgroupsPath, expand it into
+	// cgroupfs path, e.g. a-b.slice to /a.slice/a-b.slice.
+	const suffix = ".slice"
+	slice := path.Base(cgroupsParent)
+	if !strings.HasSuffix(slice, suffix) {
+		return "", errors.Errorf("invalid systemd slice %q", slice)
+	}
+	name := strings.TrimSuffix(slice, suffix)
+	if name == "-" {
+		return "/", nil
+	}
+	var p, prefix string
+	for _, component := range strings.Split(name, "-") {
+		if component == "" {
+			return "", errors.Errorf("invalid systemd slice %q", slice)
+		}
+		p += "/" + prefix + component + suffix
+		prefix += component + "-"
+	}
+	return p, nil
+}
+
 // getSandboxRootDir returns the root directory for managing sandbox files,
 // e.g. hosts files.
 func (c *criService) getSandboxRootDir(id string) string {
@@ -214,6 +263,11 @@ func getPIDNamespace(pid uint32) string {
 	return fmt.Sprintf(pidNSFormat, pid)
 }
 
+// getUserNamespace returns the user namespace of a process.
+func getUserNamespace(pid uint32) string {
+	return fmt.Sprintf(userNSFormat, pid)
+}
+
 // criContainerStateToString formats CRI container state to string.
 func criContainerStateToString(state runtime.ContainerState) string {
 	return runtime.ContainerState_name[int32(state)]
@@ -247,7 +301,11 @@ func (c *criService) localResolve(ctx context.Context, refOrID string) (*imagest
 			if err != nil {
 				return ""
 			}
-			image, err := c.client.GetImage(ctx, normalized.String())
+			cImage, err := c.client.GetImage(ctx, normalized.String())
+			if err != nil {
+				return ""
+			}
+			image, err := c.getPlatformImage(ctx, cImage)
 			if err != nil {
 				return ""
 			}
@@ -294,16 +352,20 @@ func getUserFromImage(user string) (*int64, string) {
 }
 
 // ensureImageExists returns corresponding metadata of the image reference, if image is not
-// pulled yet, the function will pull the image.
-func (c *criService) ensureImageExists(ctx context.Context, ref string) (*imagestore.Image, error) {
+// pulled yet, the function will pull the image. The image is unpacked for the snapshotter
+// if it is not unpacked yet.
+func (c *criService) ensureImageExists(ctx context.Context, ref, snapshotter string) (*imagestore.Image, error) {
 	image, err := c.localResolve(ctx, ref)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to resolve image %q", ref)
 	}
-	if image != nil {
+	if image != nil && !image.Broken {
+		if err := c.ensureImageUnpacked(ctx, image, snapshotter); err != nil {
+			return nil, err
+		}
 		return image, nil
 	}
-	// Pull image to ensure the image exists
+	// Pull image to ensure the image exists, broken image is pulled again.
 	resp, err := c.PullImage(ctx, &runtime.PullImageRequest{Image: &runtime.ImageSpec{Image: ref}})
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to pull image %q", ref)
@@ -314,9 +376,62 @@ func (c *criService) ensureImageExists(ctx context.Context, ref string) (*images
 		// It's still possible that someone removed the image right after it is pulled.
 		return nil, errors.Wrapf(err, "failed to get image %q metadata after pulling", imageID)
 	}
+	if err := c.ensureImageUnpacked(ctx, &newImage, snapshotter); err != nil {
+		return nil, err
+	}
 	return &newImage, nil
 }
 
+// ensureImageUnpacked unpacks the image for the snapshotter if it is not
+// unpacked yet.
+func (c *criService) ensureImageUnpacked(ctx context.Context, image *imagestore.Image, snapshotter string) error {
+	unpacked, err := image.Image.IsUnpacked(ctx, snapshotter)
+	if err != nil {
+		return errors.Wrapf(err, "failed to check whether image %q is unpacked for snapshotter %q", image.ID, snapshotter)
+	}
+	if unpacked {
+		return nil
+	}
+	logrus.Debugf("Unpack image %q for snapshotter %q", image.ID, snapshotter)
+	if err := image.Image.Unpack(ctx, snapshotter); err != nil {
+		return errors.Wrapf(err, "failed to unpack image %q for snapshotter %q", image.ID, snapshotter)
+	}
+	return nil
+}
+
+// getSnapshotters returns all snapshotters in use, including the global
+// snapshotter and snapshotters of all runtimes. The global snapshotter is
+// always the first one.
+func (c *criService) getSnapshotters() []string {
+	snapshotters := []string{c.config.ContainerdConfig.Snapshotter}
+	var names []string
+	for name := range c.config.ContainerdConfig.Runtimes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		sn := c.config.ContainerdConfig.Runtimes[name].Snapshotter
+		if sn != "" && !util.InStringSlice(snapshotters, sn) {
+			snapshotters = append(snapshotters, sn)
+		}
+	}
+	return snapshotters
+}
+
+// getImageFSPath returns the image filesystem path of the snapshotter.
+func (c *criService) getImageFSPath(snapshotter string) string {
+	if snapshotter == c.config.ContainerdConfig.Snapshotter {
+		return c.imageFSPath
+	}
+	return imageFSPath(c.config.ContainerdRootDir, snapshotter)
+}
+
+// getPlatformImage selects the manifest of the configured platforms for the
+// containerd image.
+func (c *criService) getPlatformImage(ctx context.Context, image containerd.Image) (*ctrdutil.PlatformImage, error) {
+	return ctrdutil.NewPlatformImage(ctx, c.client, image, c.config.ParsedPlatforms)
+}
+
 // imageInfo is the information about the image got from containerd.
 type imageInfo struct {
 	id        string
@@ -362,6 +477,47 @@ func getImageInfo(ctx context.Context, image containerd.Image) (*imageInfo, erro
 	}, nil
 }
 
+// updateImageTimeLabel sets a time label on the image id reference in containerd.
+func (c *criService) updateImageTimeLabel(ctx context.Context, id, key string, t time.Time) error {
+	img := containerdimages.Image{
+		Name:   id,
+		Labels: map[string]string{key: t.Format(time.RFC3339Nano)},
+	}
+	_, err := c.client.ImageService().Update(ctx, img, "labels."+key)
+	return err
+}
+
+// markImageUsed records that the image is used now. It is best effort,
+// failures are only logged.
+func (c *criService) markImageUsed(ctx context.Context, image *imagestore.Image) {
+	now := time.Now()
+	if err := c.updateImageTimeLabel(ctx, image.ID, imageLastUsedLabel, now); err != nil {
+		logrus.WithError(err).Warnf("Failed to update last used time of image %q", image.ID)
+	}
+	if err := c.imageStore.UpdateLastUsed(image.ID, now.UnixNano()); err != nil {
+		logrus.WithError(err).Warnf("Failed to update last used time of image %q in store", image.ID)
+	}
+}
+
+// getImageTimesFromLabels gets the pulled and last used time (in nanoseconds)
+// of an image from its labels. 0 is returned for a time which is missing or
+// invalid.
+func getImageTimesFromLabels(labels map[string]string) (pulledAt int64, lastUsedAt int64) {
+	parse := func(key string) int64 {
+		v, ok := labels[key]
+		if !ok {
+			return 0
+		}
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			logrus.WithError(err).Warnf("Invalid image label %q=%q", key, v)
+			return 0
+		}
+		return t.UnixNano()
+	}
+	return parse(imagePulledLabel), parse(imageLastUsedLabel)
+}
+
 func initSelinuxOpts(selinuxOpt *runtime.SELinuxOption) (string, string, error) {
 	if selinuxOpt == nil {
 		return "", "", nil
@@ -383,6 +539,60 @@ func initSelinuxOpts(selinuxOpt *runtime.SELinuxOption) (string, string, error)
 	return label.InitLabels(selinux.DupSecOpt(labelOpts))
 }
 
+// relabelExcludedPaths are system directories which must never be relabeled.
+var relabelExcludedPaths = map[string]bool{
+	"/":      true,
+	"/bin":   true,
+	"/boot":  true,
+	"/dev":   true,
+	"/etc":   true,
+	"/home":  true,
+	"/lib":   true,
+	"/lib64": true,
+	"/proc":  true,
+	"/root":  true,
+	"/run":   true,
+	"/sbin":  true,
+	"/sys":   true,
+	"/usr":   true,
+	"/var":   true,
+}
+
+// relabelExcludedTrees are system directories whose sub paths must never be
+// relabeled either.
+var relabelExcludedTrees = []string{"/etc", "/usr"}
+
+// checkRelabelPath returns error if the path is a system directory which
+// must not be relabeled.
+func checkRelabelPath(path string) error {
+	path = filepath.Clean(path)
+	if relabelExcludedPaths[path] {
+		return errors.Errorf("selinux relabeling of %q is not allowed", path)
+	}
+	for _, tree := range relabelExcludedTrees {
+		if strings.HasPrefix(path, tree+"/") {
+			return errors.Errorf("selinux relabeling of %q under %q is not allowed", path, tree)
+		}
+	}
+	return nil
+}
+
+// relabelPath relabels the path with the mount label. A shared label is
+// accessible by all containers in the sandbox. It returns error if the path
+// is a system directory.
+func relabelPath(path, mountLabel string, shared bool) error {
+	if mountLabel == "" {
+		return nil
+	}
+	if err := checkRelabelPath(path); err != nil {
+		return err
+	}
+	if err := label.Relabel(path, mountLabel, shared); err != nil && err != unix.ENOTSUP {
+		return errors.Wrapf(err, "relabel %q with %q failed", path, mountLabel)
+	}
+	return nil
+}
+
 // isInCRIMounts checks whether a destination is in CRI mount list.
 func isInCRIMounts(dst string, mounts []*runtime.Mount) bool {
 	for _, m := range mounts {
@@ -442,5 +652,38 @@ func getRuntimeConfigFromContainerInfo(c containers.Container) (criconfig.Runtim
 	runtimeOpts := data.(*runctypes.RuncOptions)
 	r.Engine = runtimeOpts.Runtime
 	r.Root = runtimeOpts.RuntimeRoot
+	r.SystemdCgroup = runtimeOpts.SystemdCgroup
+	r.Snapshotter = c.Snapshotter
 	return r, nil
 }
+
+// getSandboxSnapshotter returns the snapshotter used by containers in the
+// sandbox. It is the snapshotter of the sandbox instead of the current
+// runtime config, which may have changed since the sandbox is created.
+func (c *criService) getSandboxSnapshotter(sandbox sandboxstore.Sandbox) string {
+	if sandbox.Snapshotter != "" {
+		return sandbox.Snapshotter
+	}
+	return c.config.ContainerdConfig.Snapshotter
+}
+
+// getSandboxRuntimeConfig gets the runtime configuration of the runtime handler
+// of an existing sandbox.
+func (c *criService) getSandboxRuntimeConfig(ctx context.Context, sandbox sandboxstore.Sandbox) (criconfig.Runtime, error) {
+	if sandbox.RuntimeHandler != "" {
+		r, ok := c.config.ContainerdConfig.Runtimes[sandbox.RuntimeHandler]
+		if !ok {
+			return criconfig.Runtime{}, errors.Errorf("runtime %q of sandbox %q is not configured",
+				sandbox.RuntimeHandler, sandbox.ID)
+		}
+		return r, nil
+	}
+	// The runtime handler is not recorded for sandboxes created before
+	// runtime handlers are supported, get the runtime from the sandbox
+	// container instead.
+	info, err := sandbox.Container.Info(ctx)
+	if err != nil {
+		return criconfig.Runtime{}, errors.Wrapf(err, "failed to get sandbox %q info", sandbox.ID)
+	}
+	return getRuntimeConfigFromContainerInfo(info)
+}
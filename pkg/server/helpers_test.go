@@ -18,6 +18,7 @@ package server
 
 import (
 	"testing"
+	"time"
 
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/containers"
@@ -27,6 +28,7 @@ import (
 	"golang.org/x/net/context"
 
 	criconfig "github.com/containerd/cri/pkg/config"
+	sandboxstore "github.com/containerd/cri/pkg/store/sandbox"
 	"github.com/containerd/cri/pkg/util"
 )
 
@@ -132,6 +134,54 @@ func TestGetCgroupsPath(t *testing.T) {
 	}
 }
 
+func TestGetPodCgroupsPath(t *testing.T) {
+	for desc, test := range map[string]struct {
+		cgroupsParent string
+		systemdCgroup bool
+		expected      string
+		expectErr     bool
+	}{
+		"should return cgroups parent for regular cgroup path": {
+			cgroupsParent: "/a/b",
+			expected:      "/a/b",
+		},
+		"should expand the last systemd slice": {
+			cgroupsParent: "/a.slice/a-b.slice/a-b-c.slice",
+			systemdCgroup: true,
+			expected:      "/a.slice/a-b.slice/a-b-c.slice",
+		},
+		"should expand systemd slice name": {
+			cgroupsParent: "kubepods-besteffort.slice",
+			systemdCgroup: true,
+			expected:      "/kubepods.slice/kubepods-besteffort.slice",
+		},
+		"should support root systemd slice": {
+			cgroupsParent: "-.slice",
+			systemdCgroup: true,
+			expected:      "/",
+		},
+		"should return error for invalid systemd slice": {
+			cgroupsParent: "/a/b",
+			systemdCgroup: true,
+			expectErr:     true,
+		},
+		"should return error for systemd slice with empty component": {
+			cgroupsParent: "a--b.slice",
+			systemdCgroup: true,
+			expectErr:     true,
+		},
+	} {
+		t.Logf("TestCase %q", desc)
+		got, err := getPodCgroupsPath(test.cgroupsParent, test.systemdCgroup)
+		if test.expectErr {
+			assert.Error(t, err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, got)
+	}
+}
+
 func TestBuildLabels(t *testing.T) {
 	configLabels := map[string]string{
 		"a": "b",
@@ -165,9 +215,10 @@ func TestGetRuntimeConfigFromContainerInfo(t *testing.T) {
 			engine: "test-engine",
 			root:   "/test/root",
 			expectedRuntime: criconfig.Runtime{
-				Type:   "test.type",
-				Engine: "test-engine",
-				Root:   "/test/root",
+				Type:        "test.type",
+				Engine:      "test-engine",
+				Root:        "/test/root",
+				Snapshotter: "test-snapshotter",
 			},
 		},
 	} {
@@ -179,7 +230,7 @@ func TestGetRuntimeConfigFromContainerInfo(t *testing.T) {
 					RuntimeRoot: test.root,
 				}
 			}
-			c := containers.Container{}
+			c := containers.Container{Snapshotter: "test-snapshotter"}
 			assert.NoError(t, containerd.WithRuntime(
 				test.typ,
 				opts,
@@ -190,3 +241,112 @@ func TestGetRuntimeConfigFromContainerInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSandboxSnapshotter(t *testing.T) {
+	c := newTestCRIService()
+	c.config.ContainerdConfig.Snapshotter = "global-snapshotter"
+	c.config.ContainerdConfig.Runtimes = map[string]criconfig.Runtime{
+		"test-handler": {Snapshotter: "new-runtime-snapshotter"},
+	}
+	for desc, test := range map[string]struct {
+		sandbox             sandboxstore.Sandbox
+		expectedSnapshotter string
+	}{
+		"should use snapshotter of sandbox created before runtime handlers are supported": {
+			sandbox: sandboxstore.NewSandbox(
+				sandboxstore.Metadata{ID: "test-id", Snapshotter: "old-snapshotter"},
+				sandboxstore.Status{},
+			),
+			expectedSnapshotter: "old-snapshotter",
+		},
+		"should use snapshotter of sandbox even if runtime snapshotter is changed": {
+			sandbox: sandboxstore.NewSandbox(
+				sandboxstore.Metadata{ID: "test-id", RuntimeHandler: "test-handler", Snapshotter: "old-snapshotter"},
+				sandboxstore.Status{},
+			),
+			expectedSnapshotter: "old-snapshotter",
+		},
+		"should use global snapshotter if sandbox snapshotter is not set": {
+			sandbox: sandboxstore.NewSandbox(
+				sandboxstore.Metadata{ID: "test-id"},
+				sandboxstore.Status{},
+			),
+			expectedSnapshotter: "global-snapshotter",
+		},
+	} {
+		t.Logf("TestCase %q", desc)
+		assert.Equal(t, test.expectedSnapshotter, c.getSandboxSnapshotter(test.sandbox))
+	}
+}
+
+func TestGetImageTimesFromLabels(t *testing.T) {
+	pulled := time.Unix(100, 1)
+	lastUsed := time.Unix(200, 2)
+	for desc, test := range map[string]struct {
+		labels           map[string]string
+		expectPulledAt   int64
+		expectLastUsedAt int64
+	}{
+		"should get times from labels": {
+			labels: map[string]string{
+				imagePulledLabel:   pulled.Format(time.RFC3339Nano),
+				imageLastUsedLabel: lastUsed.Format(time.RFC3339Nano),
+			},
+			expectPulledAt:   pulled.UnixNano(),
+			expectLastUsedAt: lastUsed.UnixNano(),
+		},
+		"should return 0 for missing labels": {
+			labels: map[string]string{"other": "label"},
+		},
+		"should return 0 for invalid labels": {
+			labels: map[string]string{
+				imagePulledLabel:   "invalid",
+				imageLastUsedLabel: lastUsed.Format(time.RFC3339Nano),
+			},
+			expectLastUsedAt: lastUsed.UnixNano(),
+		},
+	} {
+		t.Logf("TestCase %q", desc)
+		pulledAt, lastUsedAt := getImageTimesFromLabels(test.labels)
+		assert.Equal(t, test.expectPulledAt, pulledAt)
+		assert.Equal(t, test.expectLastUsedAt, lastUsedAt)
+	}
+}
+
+func TestCheckRelabelPath(t *testing.T) {
+	for desc, test := range map[string]struct {
+		path      string
+		expectErr bool
+	}{
+		"root should not be relabeled": {
+			path:      "/",
+			expectErr: true,
+		},
+		"system directory should not be relabeled": {
+			path:      "/var",
+			expectErr: true,
+		},
+		"unclean system directory should not be relabeled": {
+			path:      "/home/../home/",
+			expectErr: true,
+		},
+		"path under /etc should not be relabeled": {
+			path:      "/etc/ssl",
+			expectErr: true,
+		},
+		"path under /usr should not be relabeled": {
+			path:      "/usr/local/share",
+			expectErr: true,
+		},
+		"path under other system directory should be relabeled": {
+			path: "/var/lib/data",
+		},
+		"path with system directory prefix should be relabeled": {
+			path: "/etcd",
+		},
+	} {
+		t.Logf("TestCase %q", desc)
+		err := checkRelabelPath(test.path)
+		assert.Equal(t, test.expectErr, err != nil)
+	}
+}
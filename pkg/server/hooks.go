@@ -0,0 +1,240 @@
+/*
+Copyright 2018 The Containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	runtimespec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// ociHookVersion is the supported version of hook definitions.
+	ociHookVersion = "1.0.0"
+	// ociHookExtension is the file extension of hook definitions.
+	ociHookExtension = ".json"
+
+	hookStagePrestart  = "prestart"
+	hookStagePoststart = "poststart"
+	hookStagePoststop  = "poststop"
+)
+
+// ociHook is a hook definition in the OCI hooks.d format.
+type ociHook struct {
+	Version string           `json:"version"`
+	Hook    runtimespec.Hook `json:"hook"`
+	When    ociHookWhen      `json:"when"`
+	Stages  []string         `json:"stages"`
+}
+
+// ociHookWhen is the condition of a hook. The hook is injected if any of
+// the conditions matches.
+type ociHookWhen struct {
+	// Always injects the hook into all containers.
+	Always *bool `json:"always,omitempty"`
+	// Annotations maps annotation key regexps to value regexps. It matches
+	// if any annotation matches both a key regexp and its value regexp.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Commands are regexps matched against the container command, which is
+	// the first process arg.
+	Commands []string `json:"commands,omitempty"`
+	// HasBindMounts matches containers with bind mounts in the container
+	// config.
+	HasBindMounts *bool `json:"hasBindMounts,omitempty"`
+}
+
+// ociHooks injects hooks loaded from the hooks directory into runtime specs.
+type ociHooks struct {
+	// hooks are the valid hooks sorted by name.
+	hooks []*compiledOCIHook
+}
+
+// compiledOCIHook is a validated hook with compiled regexps.
+type compiledOCIHook struct {
+	name        string
+	hook        runtimespec.Hook
+	stages      []string
+	always      bool
+	bindMounts  bool
+	annotations map[*regexp.Regexp]*regexp.Regexp
+	commands    []*regexp.Regexp
+}
+
+// newOCIHooks loads and validates the hook definitions in the directory.
+// Hooks are only loaded at startup, and a hook which is not injected is
+// hard to notice, so an invalid hook definition fails startup.
+func newOCIHooks(dir string) (*ociHooks, error) {
+	h := &ociHooks{}
+	if dir == "" {
+		return h, nil
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			logrus.Warnf("OCI hooks directory %q doesn't exist", dir)
+			return h, nil
+		}
+		return nil, errors.Wrapf(err, "failed to read OCI hooks directory %q", dir)
+	}
+	// ReadDir returns files sorted by name, so hooks are injected in the
+	// order of their file names.
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ociHookExtension {
+			continue
+		}
+		hook, err := loadOCIHook(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid OCI hook %q", f.Name())
+		}
+		h.hooks = append(h.hooks, hook)
+	}
+	return h, nil
+}
+
+// loadOCIHook reads and validates a hook definition.
+func loadOCIHook(path string) (*compiledOCIHook, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read hook")
+	}
+	var hook ociHook
+	if err := json.Unmarshal(data, &hook); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal hook")
+	}
+	return compileOCIHook(filepath.Base(path), &hook)
+}
+
+// compileOCIHook validates the hook definition and compiles its regexps.
+func compileOCIHook(name string, hook *ociHook) (*compiledOCIHook, error) {
+	if hook.Version != ociHookVersion {
+		return nil, errors.Errorf("unsupported version %q", hook.Version)
+	}
+	if !filepath.IsAbs(hook.Hook.Path) {
+		return nil, errors.Errorf("hook path %q is not an absolute path", hook.Hook.Path)
+	}
+	if _, err := os.Stat(hook.Hook.Path); err != nil {
+		return nil, errors.Wrapf(err, "failed to stat hook path %q", hook.Hook.Path)
+	}
+	if hook.Hook.Timeout != nil && *hook.Hook.Timeout <= 0 {
+		return nil, errors.Errorf("invalid hook timeout %d", *hook.Hook.Timeout)
+	}
+	if len(hook.Stages) == 0 {
+		return nil, errors.New("no stage is specified")
+	}
+	for _, stage := range hook.Stages {
+		switch stage {
+		case hookStagePrestart, hookStagePoststart, hookStagePoststop:
+		default:
+			return nil, errors.Errorf("unknown stage %q", stage)
+		}
+	}
+	when := hook.When
+	if when.Always == nil && when.HasBindMounts == nil && len(when.Annotations) == 0 && len(when.Commands) == 0 {
+		return nil, errors.New("no condition is specified in \"when\"")
+	}
+	c := &compiledOCIHook{
+		name:        name,
+		hook:        hook.Hook,
+		stages:      hook.Stages,
+		always:      when.Always != nil && *when.Always,
+		bindMounts:  when.HasBindMounts != nil && *when.HasBindMounts,
+		annotations: make(map[*regexp.Regexp]*regexp.Regexp),
+	}
+	for k, v := range when.Annotations {
+		key, err := regexp.Compile(k)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid annotation key regexp %q", k)
+		}
+		value, err := regexp.Compile(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid annotation value regexp %q", v)
+		}
+		c.annotations[key] = value
+	}
+	for _, cmd := range when.Commands {
+		re, err := regexp.Compile(cmd)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid command regexp %q", cmd)
+		}
+		c.commands = append(c.commands, re)
+	}
+	return c, nil
+}
+
+// match returns whether the hook should be injected into the spec.
+func (c *compiledOCIHook) match(spec *runtimespec.Spec, annotations map[string]string, hasBindMounts bool) bool {
+	if c.always {
+		return true
+	}
+	for key, value := range c.annotations {
+		for k, v := range annotations {
+			if key.MatchString(k) && value.MatchString(v) {
+				return true
+			}
+		}
+	}
+	if spec.Process != nil && len(spec.Process.Args) > 0 {
+		for _, cmd := range c.commands {
+			if cmd.MatchString(spec.Process.Args[0]) {
+				return true
+			}
+		}
+	}
+	return c.bindMounts && hasBindMounts
+}
+
+// apply injects the matching hooks into the spec after the existing hooks.
+// The annotations are matched together with the spec annotations.
+// hasBindMounts is whether the container config requests bind mounts; the
+// spec mounts can't be used, because they always contain the sandbox hosts,
+// resolv.conf and shm bind mounts. It returns the names of the injected hooks.
+func (h *ociHooks) apply(spec *runtimespec.Spec, annotations map[string]string, hasBindMounts bool) []string {
+	all := make(map[string]string)
+	for k, v := range spec.Annotations {
+		all[k] = v
+	}
+	for k, v := range annotations {
+		all[k] = v
+	}
+	var names []string
+	for _, hook := range h.hooks {
+		if !hook.match(spec, all, hasBindMounts) {
+			continue
+		}
+		if spec.Hooks == nil {
+			spec.Hooks = &runtimespec.Hooks{}
+		}
+		for _, stage := range hook.stages {
+			switch stage {
+			case hookStagePrestart:
+				spec.Hooks.Prestart = append(spec.Hooks.Prestart, hook.hook)
+			case hookStagePoststart:
+				spec.Hooks.Poststart = append(spec.Hooks.Poststart, hook.hook)
+			case hookStagePoststop:
+				spec.Hooks.Poststop = append(spec.Hooks.Poststop, hook.hook)
+			}
+		}
+		names = append(names, hook.name)
+	}
+	return names
+}
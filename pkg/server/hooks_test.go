@@ -0,0 +1,328 @@
+/*
+Copyright 2018 The Containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	runtimespec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
+
+	criconfig "github.com/containerd/cri/pkg/config"
+)
+
+func TestNewOCIHooks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hooks-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	hookPath := filepath.Join(dir, "hook")
+	require.NoError(t, ioutil.WriteFile(hookPath, nil, 0755))
+	hooksDir := filepath.Join(dir, "hooks.d")
+	require.NoError(t, os.MkdirAll(filepath.Join(hooksDir, "sub.json"), 0755))
+
+	valid := `{"version": "1.0.0", "hook": {"path": "` + hookPath + `"}, "when": {"always": true}, "stages": ["prestart"]}`
+	for name, content := range map[string]string{
+		"b.json":       valid,
+		"a.json":       valid,
+		"ignored.conf": "{",
+	} {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(hooksDir, name), []byte(content), 0644))
+	}
+
+	h, err := newOCIHooks(hooksDir)
+	require.NoError(t, err)
+	var names []string
+	for _, hook := range h.hooks {
+		names = append(names, hook.name)
+	}
+	assert.Equal(t, []string{"a.json", "b.json"}, names)
+
+	for name, content := range map[string]string{
+		"invalid.json": "{",
+		"missing-hook.json": `{"version": "1.0.0", "hook": {"path": "` + filepath.Join(dir, "missing") + `"},
+			"when": {"always": true}, "stages": ["prestart"]}`,
+	} {
+		t.Logf("invalid hook %q should fail", name)
+		path := filepath.Join(hooksDir, name)
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+		_, err = newOCIHooks(hooksDir)
+		assert.Error(t, err)
+		require.NoError(t, os.Remove(path))
+	}
+
+	h, err = newOCIHooks(filepath.Join(dir, "not-exist"))
+	require.NoError(t, err)
+	assert.Empty(t, h.hooks)
+}
+
+func TestCompileOCIHook(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hooks-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	hookPath := filepath.Join(dir, "hook")
+	require.NoError(t, ioutil.WriteFile(hookPath, nil, 0755))
+	always := true
+	timeout := 0
+
+	for desc, test := range map[string]struct {
+		hook      ociHook
+		expectErr bool
+	}{
+		"valid hook should pass": {
+			hook: ociHook{
+				Version: ociHookVersion,
+				Hook:    runtimespec.Hook{Path: hookPath},
+				When:    ociHookWhen{Commands: []string{"^/bin/sh$"}},
+				Stages:  []string{hookStagePrestart, hookStagePoststop},
+			},
+		},
+		"unsupported version should fail": {
+			hook: ociHook{
+				Version: "0.1.0",
+				Hook:    runtimespec.Hook{Path: hookPath},
+				When:    ociHookWhen{Always: &always},
+				Stages:  []string{hookStagePrestart},
+			},
+			expectErr: true,
+		},
+		"relative hook path should fail": {
+			hook: ociHook{
+				Version: ociHookVersion,
+				Hook:    runtimespec.Hook{Path: "hook"},
+				When:    ociHookWhen{Always: &always},
+				Stages:  []string{hookStagePrestart},
+			},
+			expectErr: true,
+		},
+		"non-existent hook path should fail": {
+			hook: ociHook{
+				Version: ociHookVersion,
+				Hook:    runtimespec.Hook{Path: filepath.Join(dir, "missing")},
+				When:    ociHookWhen{Always: &always},
+				Stages:  []string{hookStagePrestart},
+			},
+			expectErr: true,
+		},
+		"invalid timeout should fail": {
+			hook: ociHook{
+				Version: ociHookVersion,
+				Hook:    runtimespec.Hook{Path: hookPath, Timeout: &timeout},
+				When:    ociHookWhen{Always: &always},
+				Stages:  []string{hookStagePrestart},
+			},
+			expectErr: true,
+		},
+		"no stage should fail": {
+			hook: ociHook{
+				Version: ociHookVersion,
+				Hook:    runtimespec.Hook{Path: hookPath},
+				When:    ociHookWhen{Always: &always},
+			},
+			expectErr: true,
+		},
+		"unknown stage should fail": {
+			hook: ociHook{
+				Version: ociHookVersion,
+				Hook:    runtimespec.Hook{Path: hookPath},
+				When:    ociHookWhen{Always: &always},
+				Stages:  []string{"prestop"},
+			},
+			expectErr: true,
+		},
+		"no condition should fail": {
+			hook: ociHook{
+				Version: ociHookVersion,
+				Hook:    runtimespec.Hook{Path: hookPath},
+				Stages:  []string{hookStagePrestart},
+			},
+			expectErr: true,
+		},
+		"invalid annotation regexp should fail": {
+			hook: ociHook{
+				Version: ociHookVersion,
+				Hook:    runtimespec.Hook{Path: hookPath},
+				When:    ociHookWhen{Annotations: map[string]string{"(": "value"}},
+				Stages:  []string{hookStagePrestart},
+			},
+			expectErr: true,
+		},
+		"invalid command regexp should fail": {
+			hook: ociHook{
+				Version: ociHookVersion,
+				Hook:    runtimespec.Hook{Path: hookPath},
+				When:    ociHookWhen{Commands: []string{"["}},
+				Stages:  []string{hookStagePrestart},
+			},
+			expectErr: true,
+		},
+	} {
+		t.Logf("TestCase %q", desc)
+		hook := test.hook
+		_, err := compileOCIHook("test.json", &hook)
+		assert.Equal(t, test.expectErr, err != nil)
+	}
+}
+
+func TestOCIHooksApply(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hooks-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	hookPath := filepath.Join(dir, "hook")
+	require.NoError(t, ioutil.WriteFile(hookPath, nil, 0755))
+	always := true
+
+	newHook := func(name string, when ociHookWhen, stages ...string) *compiledOCIHook {
+		c, err := compileOCIHook(name, &ociHook{
+			Version: ociHookVersion,
+			Hook:    runtimespec.Hook{Path: hookPath, Args: []string{name}},
+			When:    when,
+			Stages:  stages,
+		})
+		require.NoError(t, err)
+		return c
+	}
+	hooks := &ociHooks{hooks: []*compiledOCIHook{
+		newHook("always.json", ociHookWhen{Always: &always}, hookStagePoststop),
+		newHook("annotation.json", ociHookWhen{
+			Annotations: map[string]string{`^example\.com/gpu$`: "^true$"},
+		}, hookStagePrestart),
+		newHook("command.json", ociHookWhen{Commands: []string{`/nginx$`}}, hookStagePrestart, hookStagePoststart),
+		newHook("bind-mounts.json", ociHookWhen{HasBindMounts: &always}, hookStagePrestart),
+	}}
+
+	for desc, test := range map[string]struct {
+		spec           *runtimespec.Spec
+		annotations    map[string]string
+		bindMounts     bool
+		expectedHooks  []string
+		expectedStages map[string][]string
+	}{
+		"only always hook should be injected if nothing matches": {
+			spec: &runtimespec.Spec{
+				Process: &runtimespec.Process{Args: []string{"/bin/sh"}},
+			},
+			expectedHooks: []string{"always.json"},
+			expectedStages: map[string][]string{
+				hookStagePoststop: {"always.json"},
+			},
+		},
+		"hook should be injected if annotation matches": {
+			spec: &runtimespec.Spec{
+				Process: &runtimespec.Process{Args: []string{"/bin/sh"}},
+			},
+			annotations:   map[string]string{"example.com/gpu": "true"},
+			expectedHooks: []string{"always.json", "annotation.json"},
+			expectedStages: map[string][]string{
+				hookStagePrestart: {"annotation.json"},
+				hookStagePoststop: {"always.json"},
+			},
+		},
+		"hook should be injected if spec annotation matches": {
+			spec: &runtimespec.Spec{
+				Process:     &runtimespec.Process{Args: []string{"/bin/sh"}},
+				Annotations: map[string]string{"example.com/gpu": "true"},
+			},
+			expectedHooks: []string{"always.json", "annotation.json"},
+			expectedStages: map[string][]string{
+				hookStagePrestart: {"annotation.json"},
+				hookStagePoststop: {"always.json"},
+			},
+		},
+		"hook should not be injected if only annotation key matches": {
+			spec: &runtimespec.Spec{
+				Process: &runtimespec.Process{Args: []string{"/bin/sh"}},
+			},
+			annotations:   map[string]string{"example.com/gpu": "false"},
+			expectedHooks: []string{"always.json"},
+			expectedStages: map[string][]string{
+				hookStagePoststop: {"always.json"},
+			},
+		},
+		"hook should be injected into all stages if command matches": {
+			spec: &runtimespec.Spec{
+				Process: &runtimespec.Process{Args: []string{"/usr/sbin/nginx", "-g"}},
+			},
+			expectedHooks: []string{"always.json", "command.json"},
+			expectedStages: map[string][]string{
+				hookStagePrestart:  {"command.json"},
+				hookStagePoststart: {"command.json"},
+				hookStagePoststop:  {"always.json"},
+			},
+		},
+		"hook should be injected if container has bind mounts": {
+			spec: &runtimespec.Spec{
+				Process: &runtimespec.Process{Args: []string{"/bin/sh"}},
+			},
+			bindMounts:    true,
+			expectedHooks: []string{"always.json", "bind-mounts.json"},
+			expectedStages: map[string][]string{
+				hookStagePrestart: {"bind-mounts.json"},
+				hookStagePoststop: {"always.json"},
+			},
+		},
+		"hooks should be appended after existing hooks": {
+			spec: &runtimespec.Spec{
+				Hooks: &runtimespec.Hooks{
+					Poststop: []runtimespec.Hook{{Path: hookPath, Args: []string{"existing"}}},
+				},
+			},
+			expectedHooks: []string{"always.json"},
+			expectedStages: map[string][]string{
+				hookStagePoststop: {"existing", "always.json"},
+			},
+		},
+	} {
+		t.Logf("TestCase %q", desc)
+		injected := hooks.apply(test.spec, test.annotations, test.bindMounts)
+		assert.Equal(t, test.expectedHooks, injected)
+		require.NotNil(t, test.spec.Hooks)
+		for stage, specHooks := range map[string][]runtimespec.Hook{
+			hookStagePrestart:  test.spec.Hooks.Prestart,
+			hookStagePoststart: test.spec.Hooks.Poststart,
+			hookStagePoststop:  test.spec.Hooks.Poststop,
+		} {
+			var names []string
+			for _, h := range specHooks {
+				names = append(names, h.Args[0])
+			}
+			assert.Equal(t, test.expectedStages[stage], names, stage)
+		}
+	}
+
+	t.Logf("sandbox mounts in container spec should not match bind mounts condition")
+	c := newTestCRIService()
+	for _, mounts := range [][]*runtime.Mount{
+		nil,
+		{{ContainerPath: "/test-container-path", HostPath: "/test-host-path"}},
+	} {
+		config, sandboxConfig, imageConfig, _ := getCreateContainerTestData()
+		config.Mounts = mounts
+		spec, err := c.generateContainerSpec("test-id", "sandbox-id", 1234, config, sandboxConfig,
+			criconfig.Runtime{}, imageConfig, c.generateContainerMounts("sandbox-id", config))
+		require.NoError(t, err)
+		expected := []string{"always.json"}
+		if len(mounts) > 0 {
+			expected = append(expected, "bind-mounts.json")
+		}
+		assert.Equal(t, expected, hooks.apply(spec, config.GetAnnotations(), len(config.GetMounts()) > 0))
+	}
+}
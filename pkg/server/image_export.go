@@ -0,0 +1,130 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bufio"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+
+	api "github.com/containerd/cri/pkg/api/v1"
+	"github.com/containerd/cri/pkg/containerd/exporter"
+	ctrdutil "github.com/containerd/cri/pkg/containerd/util"
+	imagestore "github.com/containerd/cri/pkg/store/image"
+	"github.com/containerd/cri/pkg/util"
+)
+
+// exportChunkSize is the size of archive chunks sent to the client.
+const exportChunkSize = 1 << 20
+
+// ExportImage exports images into an archive streamed to the client.
+func (c *criService) ExportImage(r *api.ExportImageRequest, stream api.CRIPluginService_ExportImageServer) error {
+	ctx := stream.Context()
+	if len(r.GetImages()) == 0 {
+		return errors.New("no image to export")
+	}
+	var format exporter.Format
+	switch r.GetFormat() {
+	case api.ExportFormat_DOCKER:
+		format = exporter.FormatDocker
+	case api.ExportFormat_OCI:
+		format = exporter.FormatOCI
+	default:
+		return errors.Errorf("unsupported export format %v", r.GetFormat())
+	}
+	imgs, err := c.getExportImages(ctx, r.GetImages())
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriterSize(&exportStreamWriter{stream: stream}, exportChunkSize)
+	if err := exporter.Export(ctx, c.client.ContentStore(), w, imgs, format); err != nil {
+		return errors.Wrap(err, "failed to export images")
+	}
+	if err := w.Flush(); err != nil {
+		return errors.Wrap(err, "failed to send archive")
+	}
+	return nil
+}
+
+// getExportImages resolves the images to export. An image referenced by tag
+// or digest is exported with the reference, an image referenced by id is
+// exported with all its tags and digests. Images referenced more than once
+// are only exported once.
+func (c *criService) getExportImages(ctx context.Context, refs []string) ([]exporter.Image, error) {
+	var (
+		images []imagestore.Image
+		names  = make(map[string][]string)
+	)
+	for _, ref := range refs {
+		image, err := c.localResolve(ctx, ref)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to resolve image %q", ref)
+		}
+		if image == nil {
+			return nil, errors.Errorf("image %q not found", ref)
+		}
+		if _, ok := names[image.ID]; !ok {
+			images = append(images, *image)
+			names[image.ID] = nil
+		}
+		for _, n := range getExportNames(*image, ref) {
+			if !util.InStringSlice(names[image.ID], n) {
+				names[image.ID] = append(names[image.ID], n)
+			}
+		}
+	}
+	var imgs []exporter.Image
+	for _, image := range images {
+		manifest, err := ctrdutil.ResolveManifest(ctx, c.client.ContentStore(), image.Image.Target(), c.config.ParsedPlatforms)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to resolve manifest of image %q", image.ID)
+		}
+		imgs = append(imgs, exporter.Image{
+			Names:    names[image.ID],
+			Manifest: manifest,
+		})
+	}
+	return imgs, nil
+}
+
+// getExportNames returns the names to export the image with when it is
+// referenced by ref.
+func getExportNames(image imagestore.Image, ref string) []string {
+	if normalized, err := util.NormalizeImageRef(ref); err == nil {
+		name := normalized.String()
+		for _, n := range append(image.RepoTags, image.RepoDigests...) {
+			if n == name {
+				return []string{name}
+			}
+		}
+	}
+	return append(append([]string{}, image.RepoTags...), image.RepoDigests...)
+}
+
+// exportStreamWriter sends written data to the client.
+type exportStreamWriter struct {
+	stream api.CRIPluginService_ExportImageServer
+}
+
+// Write sends the data as a chunk of the archive.
+func (w *exportStreamWriter) Write(p []byte) (int, error) {
+	if err := w.stream.Send(&api.ExportImageResponse{Data: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"golang.org/x/sys/unix"
+
+	ctrdutil "github.com/containerd/cri/pkg/containerd/util"
+	containerstore "github.com/containerd/cri/pkg/store/container"
+	imagestore "github.com/containerd/cri/pkg/store/image"
+	snapshotstore "github.com/containerd/cri/pkg/store/snapshot"
+	"github.com/containerd/cri/pkg/util"
+)
+
+// imageRecord records when an image is detected and last used.
+type imageRecord struct {
+	// firstDetected is the time when the image is first detected.
+	firstDetected time.Time
+	// lastUsed is the last time when the image is used by a container.
+	lastUsed time.Time
+}
+
+// lastUsedOrDetected returns the last used time, or the first detected
+// time if the image is never used.
+func (r *imageRecord) lastUsedOrDetected() time.Time {
+	if r.lastUsed.IsZero() {
+		return r.firstDetected
+	}
+	return r.lastUsed
+}
+
+// imageGCManager removes least recently used images which are not used
+// by any container when image filesystem usage exceeds the high threshold,
+// until the usage is below the low threshold.
+type imageGCManager struct {
+	imageStore     *imagestore.Store
+	containerStore *containerstore.Store
+	snapshotStore  *snapshotstore.Store
+	// snapshotter is the snapshotter on the image filesystem.
+	snapshotter string
+	gcPeriod    time.Duration
+	// minAge is the minimum age of an image since it is first detected
+	// before it could be removed, so that images just pulled are not
+	// removed before they are used.
+	minAge time.Duration
+	// highThresholdPercent and lowThresholdPercent are the image filesystem
+	// usage watermarks.
+	highThresholdPercent int
+	lowThresholdPercent  int
+	// pinnedImages are references or ids of images which are never removed.
+	pinnedImages []string
+	// fsCapacity returns the capacity of the image filesystem in bytes.
+	fsCapacity func() (uint64, error)
+	// removeImage removes the image.
+	removeImage func(context.Context, imagestore.Image) error
+	// records are records of all images, it is only accessed in the gc loop.
+	records map[string]*imageRecord
+}
+
+// newImageGCManager creates an image gc manager.
+func newImageGCManager(imageStore *imagestore.Store, containerStore *containerstore.Store,
+	snapshotStore *snapshotstore.Store, snapshotter string, period, minAge time.Duration, high, low int, pinnedImages []string,
+	fsCapacity func() (uint64, error), removeImage func(context.Context, imagestore.Image) error) *imageGCManager {
+	return &imageGCManager{
+		imageStore:           imageStore,
+		containerStore:       containerStore,
+		snapshotStore:        snapshotStore,
+		snapshotter:          snapshotter,
+		gcPeriod:             period,
+		minAge:               minAge,
+		highThresholdPercent: high,
+		lowThresholdPercent:  low,
+		pinnedImages:         pinnedImages,
+		fsCapacity:           fsCapacity,
+		removeImage:          removeImage,
+		records:              make(map[string]*imageRecord),
+	}
+}
+
+// start starts the image gc manager. No stop function is needed because
+// image removal is synchronous, it's fine to let it exit with the process.
+func (g *imageGCManager) start() {
+	tick := time.NewTicker(g.gcPeriod)
+	go func() {
+		defer tick.Stop()
+		for {
+			if err := g.gc(); err != nil {
+				logrus.WithError(err).Error("Failed to garbage collect images")
+			}
+			<-tick.C
+		}
+	}()
+}
+
+// gc removes images if image filesystem usage exceeds the high threshold.
+func (g *imageGCManager) gc() error {
+	now := time.Now()
+	inUse := g.detectImages(now)
+	capacity, err := g.fsCapacity()
+	if err != nil {
+		return errors.Wrap(err, "failed to get image filesystem capacity")
+	}
+	if capacity == 0 {
+		return errors.New("invalid zero image filesystem capacity")
+	}
+	// Image filesystem usage is the same with ImageFsInfo.
+	var usage uint64
+	for _, sn := range g.snapshotStore.List() {
+		if sn.Snapshotter == g.snapshotter {
+			usage += sn.Size
+		}
+	}
+	if usage*100 < capacity*uint64(g.highThresholdPercent) {
+		return nil
+	}
+	toFree := usage - capacity*uint64(g.lowThresholdPercent)/100
+	logrus.Infof("Image filesystem usage %d bytes exceeds %d%% of capacity %d bytes, try to free %d bytes",
+		usage, g.highThresholdPercent, capacity, toFree)
+	freed, err := g.freeSpace(now, inUse, toFree)
+	if freed >= toFree {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to free %d bytes, only %d bytes freed", toFree, freed)
+	}
+	return errors.Errorf("failed to free %d bytes, only %d bytes freed", toFree, freed)
+}
+
+// detectImages updates image records, and returns images in use.
+func (g *imageGCManager) detectImages(now time.Time) map[string]bool {
+	inUse := make(map[string]bool)
+	for _, cntr := range g.containerStore.List() {
+		inUse[cntr.ImageRef] = true
+	}
+	current := make(map[string]bool)
+	for _, image := range g.imageStore.List() {
+		current[image.ID] = true
+		r, ok := g.records[image.ID]
+		if !ok {
+			r = &imageRecord{firstDetected: now}
+			// Use the recorded time if the image is known before, e.g.
+			// before restart.
+			if image.PulledAt != 0 {
+				r.firstDetected = time.Unix(0, image.PulledAt)
+			}
+			if image.LastUsedAt != 0 {
+				r.lastUsed = time.Unix(0, image.LastUsedAt)
+			}
+			g.records[image.ID] = r
+		}
+		if inUse[image.ID] {
+			r.lastUsed = now
+		}
+	}
+	for id := range g.records {
+		if !current[id] {
+			delete(g.records, id)
+		}
+	}
+	return inUse
+}
+
+// freeSpace removes least recently used images which are not in use,
+// pinned or younger than the minimum age, until the given bytes are freed.
+// Images never used are considered used when they are first detected. The
+// compressed image size is used as an estimation of freed bytes. It returns
+// the bytes freed and the last removal error if any.
+func (g *imageGCManager) freeSpace(now time.Time, inUse map[string]bool, toFree uint64) (uint64, error) {
+	var candidates []imagestore.Image
+	for _, image := range g.imageStore.List() {
+		if inUse[image.ID] || isPinnedImage(image, g.pinnedImages) {
+			continue
+		}
+		r, ok := g.records[image.ID]
+		if !ok {
+			// The image is added after detection, skip it.
+			continue
+		}
+		if now.Sub(r.firstDetected) < g.minAge {
+			continue
+		}
+		candidates = append(candidates, image)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		ri, rj := g.records[candidates[i].ID], g.records[candidates[j].ID]
+		if ui, uj := ri.lastUsedOrDetected(), rj.lastUsedOrDetected(); !ui.Equal(uj) {
+			return ui.Before(uj)
+		}
+		return ri.firstDetected.Before(rj.firstDetected)
+	})
+
+	ctx := ctrdutil.NamespacedContext()
+	var freed uint64
+	var lastErr error
+	for _, image := range candidates {
+		if freed >= toFree {
+			break
+		}
+		r := g.records[image.ID]
+		if err := g.removeImage(ctx, image); err != nil {
+			logrus.WithError(err).Errorf("Failed to garbage collect image %q", image.ID)
+			lastErr = err
+			continue
+		}
+		logrus.Infof("Garbage collected image %q (tags %v, size %d bytes, last used %v, first detected %v)",
+			image.ID, image.RepoTags, image.Size, r.lastUsed, r.firstDetected)
+		delete(g.records, image.ID)
+		freed += uint64(image.Size)
+	}
+	return freed, lastErr
+}
+
+// isPinnedImage returns true if the image matches any of the pinned image
+// references or ids.
+func isPinnedImage(image imagestore.Image, pinnedImages []string) bool {
+	for _, ref := range pinnedImages {
+		if ref == image.ID {
+			return true
+		}
+		normalized, err := util.NormalizeImageRef(ref)
+		if err != nil {
+			continue
+		}
+		if util.InStringSlice(image.RepoTags, normalized.String()) ||
+			util.InStringSlice(image.RepoDigests, normalized.String()) {
+			return true
+		}
+	}
+	return false
+}
+
+// getFSCapacity returns the capacity in bytes of the filesystem of the path.
+func getFSCapacity(path string) (uint64, error) {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(path, &stat); err != nil {
+		return 0, errors.Wrapf(err, "failed to statfs %q", path)
+	}
+	return stat.Blocks * uint64(stat.Bsize), nil
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+
+	containerstore "github.com/containerd/cri/pkg/store/container"
+	imagestore "github.com/containerd/cri/pkg/store/image"
+	snapshotstore "github.com/containerd/cri/pkg/store/snapshot"
+)
+
+const (
+	testImageID1 = "sha256:1123456789abcdef1123456789abcdef1123456789abcdef1123456789abcdef"
+	testImageID2 = "sha256:2123456789abcdef2123456789abcdef2123456789abcdef2123456789abcdef"
+	testImageID3 = "sha256:3123456789abcdef3123456789abcdef3123456789abcdef3123456789abcdef"
+	testImageID4 = "sha256:4123456789abcdef4123456789abcdef4123456789abcdef4123456789abcdef"
+	testImageID5 = "sha256:5123456789abcdef5123456789abcdef5123456789abcdef5123456789abcdef"
+	testImageID6 = "sha256:6123456789abcdef6123456789abcdef6123456789abcdef6123456789abcdef"
+)
+
+func TestImageGC(t *testing.T) {
+	const minAge = time.Minute
+	now := time.Now()
+	base := now.Add(-time.Hour)
+	images := []imagestore.Image{
+		{ID: testImageID1, RepoTags: []string{"docker.io/library/image-1:latest"}, Size: 10},
+		{ID: testImageID2, RepoTags: []string{"docker.io/library/image-2:latest"}, Size: 20},
+		{ID: testImageID3, RepoTags: []string{"docker.io/library/image-3:latest"}, Size: 30},
+		{ID: testImageID4, RepoTags: []string{"docker.io/library/image-4:latest"}, Size: 40},
+		{ID: testImageID5, RepoTags: []string{"docker.io/library/image-5:latest"}, Size: 5},
+		{ID: testImageID6, RepoTags: []string{"docker.io/library/image-6:latest"}, Size: 60},
+	}
+	// Image 4 is the least recently used, image 1 is the most recently used.
+	// Image 5 is never used, and is considered used when first detected.
+	// Image 6 is just pulled and never used, it is younger than min age.
+	records := map[string]*imageRecord{
+		testImageID1: {firstDetected: base, lastUsed: base.Add(3 * time.Second)},
+		testImageID2: {firstDetected: base, lastUsed: base.Add(2 * time.Second)},
+		testImageID3: {firstDetected: base, lastUsed: base.Add(time.Second)},
+		testImageID4: {firstDetected: base},
+		testImageID5: {firstDetected: base.Add(2500 * time.Millisecond)},
+		testImageID6: {firstDetected: now},
+	}
+	for desc, test := range map[string]struct {
+		usage         uint64
+		containers    []string
+		pinned        []string
+		expectRemoved []string
+		expectErr     bool
+	}{
+		"should not remove images below high threshold": {
+			usage: 84,
+		},
+		"should remove least recently used images until below low threshold": {
+			usage:         90,
+			expectRemoved: []string{testImageID4},
+		},
+		"should remove more images if needed": {
+			usage:         130,
+			expectRemoved: []string{testImageID4, testImageID3},
+		},
+		"should skip images used by containers": {
+			usage:         90,
+			containers:    []string{testImageID4},
+			expectRemoved: []string{testImageID3},
+		},
+		"should skip pinned images": {
+			usage:         90,
+			pinned:        []string{"image-4", testImageID3},
+			expectRemoved: []string{testImageID2},
+		},
+		"should remove never used image by its first detected time": {
+			usage:         105,
+			containers:    []string{testImageID4, testImageID3},
+			expectRemoved: []string{testImageID2, testImageID5},
+		},
+		"should not remove images younger than min age": {
+			usage:      90,
+			containers: []string{testImageID1, testImageID2, testImageID3, testImageID4, testImageID5},
+			expectErr:  true,
+		},
+		"should return error if not enough space could be freed": {
+			usage:         200,
+			containers:    []string{testImageID1},
+			pinned:        []string{"image-2"},
+			expectRemoved: []string{testImageID4, testImageID3, testImageID5},
+			expectErr:     true,
+		},
+	} {
+		t.Logf("TestCase %q", desc)
+		imageStore := imagestore.NewStore()
+		for _, image := range images {
+			require.NoError(t, imageStore.Add(image))
+		}
+		containerStore := containerstore.NewStore()
+		for i, imageRef := range test.containers {
+			cntr, err := containerForTest{
+				metadata: containerstore.Metadata{ID: fmt.Sprintf("container-%d", i), ImageRef: imageRef},
+			}.toContainer()
+			require.NoError(t, err)
+			require.NoError(t, containerStore.Add(cntr))
+		}
+		snapshotStore := snapshotstore.NewStore()
+		snapshotStore.Add(snapshotstore.Snapshot{Snapshotter: "test-snapshotter", Key: "test-snapshot", Size: test.usage})
+		// Snapshots of other snapshotters are not on the image filesystem.
+		snapshotStore.Add(snapshotstore.Snapshot{Snapshotter: "other-snapshotter", Key: "test-snapshot", Size: 100})
+		var removed []string
+		g := newImageGCManager(imageStore, containerStore, snapshotStore, "test-snapshotter", time.Second, minAge, 85, 80, test.pinned,
+			func() (uint64, error) { return 100, nil },
+			func(_ context.Context, image imagestore.Image) error {
+				removed = append(removed, image.ID)
+				imageStore.Delete(image.ID)
+				return nil
+			})
+		for id, r := range records {
+			record := *r
+			g.records[id] = &record
+		}
+		err := g.gc()
+		if test.expectErr {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, test.expectRemoved, removed)
+		for _, id := range test.expectRemoved {
+			assert.NotContains(t, g.records, id)
+		}
+	}
+}
+
+func TestIsPinnedImage(t *testing.T) {
+	image := imagestore.Image{
+		ID:          testImageID1,
+		RepoTags:    []string{"docker.io/library/busybox:latest"},
+		RepoDigests: []string{"docker.io/library/busybox@" + testImageID2},
+	}
+	for desc, test := range map[string]struct {
+		pinned []string
+		expect bool
+	}{
+		"should match image id": {
+			pinned: []string{testImageID1},
+			expect: true,
+		},
+		"should match normalized repo tag": {
+			pinned: []string{"busybox"},
+			expect: true,
+		},
+		"should match repo digest": {
+			pinned: []string{"busybox@" + testImageID2},
+			expect: true,
+		},
+		"should not match other image": {
+			pinned: []string{"busybox:1.0", "invalid-REF"},
+		},
+		"should not match without pinned images": {},
+	} {
+		t.Logf("TestCase %q", desc)
+		assert.Equal(t, test.expect, isPinnedImage(image, test.pinned))
+	}
+}
@@ -33,6 +33,10 @@ func (c *criService) ListImages(ctx context.Context, r *runtime.ListImagesReques
 	for _, image := range imagesInStore {
 		// TODO(random-liu): [P0] Make sure corresponding snapshot exists. What if snapshot
 		// doesn't exist?
+		if image.Broken {
+			// Hide broken image, so that it is pulled again.
+			continue
+		}
 		images = append(images, toCRIImage(image))
 	}
 
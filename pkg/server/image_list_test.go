@@ -67,6 +67,14 @@ func TestListImages(t *testing.T) {
 				},
 			},
 		},
+		{
+			ID:          "sha256:4123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			ChainID:     "test-chainid-4",
+			RepoTags:    []string{"tag-a-4", "tag-b-4"},
+			RepoDigests: []string{"digest-a-4", "digest-b-4"},
+			Size:        4000,
+			Broken:      true,
+		},
 	}
 	expect := []*runtime.Image{
 		{
@@ -17,12 +17,15 @@ limitations under the License.
 package server
 
 import (
+	"io"
 	"os"
 	"path/filepath"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	api "github.com/containerd/cri/pkg/api/v1"
 	"github.com/containerd/cri/pkg/containerd/importer"
@@ -37,17 +40,37 @@ func (c *criService) LoadImage(ctx context.Context, r *api.LoadImageRequest) (*a
 	}
 	f, err := os.Open(path)
 	if err != nil {
+		if os.IsNotExist(err) {
+			// Return NotFound, so that the client could stream the
+			// file instead.
+			return nil, status.Errorf(codes.NotFound, "file %q not found", path)
+		}
 		return nil, errors.Wrap(err, "failed to open file")
 	}
-	repoTags, err := importer.Import(ctx, c.client, f)
+	defer f.Close()
+	repoTags, err := c.loadImage(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	return &api.LoadImageResponse{Images: repoTags}, nil
+}
+
+// loadImage imports images from the archive read from the reader, and
+// returns the references of imported images.
+func (c *criService) loadImage(ctx context.Context, reader io.Reader) ([]string, error) {
+	repoTags, err := importer.Import(ctx, c.client, reader, c.config.ParsedPlatforms)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to import image")
 	}
 	for _, repoTag := range repoTags {
-		image, err := c.client.GetImage(ctx, repoTag)
+		cImage, err := c.client.GetImage(ctx, repoTag)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to get image %q", repoTag)
 		}
+		image, err := c.getPlatformImage(ctx, cImage)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to select platform of image %q", repoTag)
+		}
 		if err := image.Unpack(ctx, c.config.ContainerdConfig.Snapshotter); err != nil {
 			logrus.WithError(err).Warnf("Failed to unpack image %q", repoTag)
 			// Do not fail image importing. Unpack will be retried when container creation.
@@ -69,6 +92,7 @@ func (c *criService) LoadImage(ctx context.Context, r *api.LoadImageRequest) (*a
 			Size:      info.size,
 			ImageSpec: info.imagespec,
 			Image:     image,
+			Platform:  image.Platform(),
 		}
 
 		if err := c.imageStore.Add(img); err != nil {
@@ -76,5 +100,5 @@ func (c *criService) LoadImage(ctx context.Context, r *api.LoadImageRequest) (*a
 		}
 		logrus.Debugf("Imported image with id %q, repo tag %q", id, repoTag)
 	}
-	return &api.LoadImageResponse{Images: repoTags}, nil
+	return repoTags, nil
 }
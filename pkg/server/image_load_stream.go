@@ -0,0 +1,55 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+
+	api "github.com/containerd/cri/pkg/api/v1"
+)
+
+// LoadImageStream loads a image into containerd from the archive streamed
+// by the client.
+func (c *criService) LoadImageStream(stream api.CRIPluginService_LoadImageStreamServer) error {
+	pr, pw := io.Pipe()
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err == io.EOF {
+				pw.Close() // nolint: errcheck
+				return
+			}
+			if err != nil {
+				pw.CloseWithError(errors.Wrap(err, "failed to receive archive")) // nolint: errcheck
+				return
+			}
+			if _, err := pw.Write(req.GetData()); err != nil {
+				// The reader is closed, stop receiving.
+				return
+			}
+		}
+	}()
+	repoTags, err := c.loadImage(stream.Context(), pr)
+	// Unblock the receiving goroutine if the archive is not fully read.
+	pr.CloseWithError(errors.New("image loading is finished")) // nolint: errcheck
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(&api.LoadImageResponse{Images: repoTags})
+}
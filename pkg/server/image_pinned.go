@@ -0,0 +1,98 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
+
+	ctrdutil "github.com/containerd/cri/pkg/containerd/util"
+	"github.com/containerd/cri/pkg/util"
+)
+
+const (
+	// pinnedImagePullInitialBackoff is the initial backoff of retrying
+	// pinned image pulling.
+	pinnedImagePullInitialBackoff = 5 * time.Second
+	// pinnedImagePullMaxBackoff is the max backoff of retrying pinned
+	// image pulling.
+	pinnedImagePullMaxBackoff = 5 * time.Minute
+)
+
+// getPinnedImages returns references of all pinned images, including the
+// sandbox images of all runtimes.
+func (c *criService) getPinnedImages() []string {
+	pinned := []string{c.config.SandboxImage}
+	var names []string
+	for name := range c.config.ContainerdConfig.Runtimes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ref := c.config.ContainerdConfig.Runtimes[name].SandboxImage
+		if ref != "" && !util.InStringSlice(pinned, ref) {
+			pinned = append(pinned, ref)
+		}
+	}
+	for _, ref := range c.config.PinnedImages {
+		if !util.InStringSlice(pinned, ref) {
+			pinned = append(pinned, ref)
+		}
+	}
+	return pinned
+}
+
+// pullPinnedImages pulls all pinned images which don't exist locally in
+// the background. Pulling is retried with backoff until it succeeds.
+func (c *criService) pullPinnedImages() {
+	for _, ref := range c.getPinnedImages() {
+		go c.pullPinnedImage(ref)
+	}
+}
+
+// pullPinnedImage pulls a pinned image if it doesn't exist locally, and
+// retries with backoff until it succeeds.
+func (c *criService) pullPinnedImage(ref string) {
+	ctx := ctrdutil.NamespacedContext()
+	backoff := pinnedImagePullInitialBackoff
+	for {
+		image, err := c.localResolve(ctx, ref)
+		if err != nil {
+			logrus.WithError(err).Errorf("Failed to resolve pinned image %q", ref)
+		} else if image != nil {
+			logrus.Debugf("Pinned image %q exists as %q", ref, image.ID)
+			return
+		} else {
+			// The request carries no credential, so the node-level
+			// credential is used.
+			resp, err := c.PullImage(ctx, &runtime.PullImageRequest{Image: &runtime.ImageSpec{Image: ref}})
+			if err == nil {
+				logrus.Infof("Pulled pinned image %q as %q", ref, resp.GetImageRef())
+				return
+			}
+			logrus.WithError(err).Errorf("Failed to pull pinned image %q, retry in %v", ref, backoff)
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > pinnedImagePullMaxBackoff {
+			backoff = pinnedImagePullMaxBackoff
+		}
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
+
+	imagestore "github.com/containerd/cri/pkg/store/image"
+)
+
+func TestGetPinnedImages(t *testing.T) {
+	c := newTestCRIService()
+	c.config.PinnedImages = []string{"image-1", testSandboxImage, "image-2"}
+	assert.Equal(t, []string{testSandboxImage, "image-1", "image-2"}, c.getPinnedImages())
+}
+
+func TestRemovePinnedImage(t *testing.T) {
+	c := newTestCRIService()
+	c.config.PinnedImages = []string{"image-1"}
+	require.NoError(t, c.imageStore.Add(imagestore.Image{
+		ID:       testImageID1,
+		RepoTags: []string{"docker.io/library/image-1:latest"},
+	}))
+	_, err := c.RemoveImage(context.Background(), &runtime.RemoveImageRequest{
+		Image: &runtime.ImageSpec{Image: testImageID1},
+	})
+	assert.Error(t, err)
+	_, err = c.imageStore.Get(testImageID1)
+	assert.NoError(t, err)
+}
@@ -18,12 +18,17 @@ package server
 
 import (
 	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/errdefs"
 	containerdimages "github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/platforms"
+	"github.com/containerd/containerd/remotes"
 	imagespec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -88,11 +93,18 @@ func (c *criService) PullImage(ctx context.Context, r *runtime.PullImageRequest)
 		logrus.Debugf("PullImage using normalized image ref: %q", ref)
 	}
 	resolver := containerdresolver.NewResolver(containerdresolver.Options{
-		Credentials: func(string) (string, string, error) { return ParseAuth(r.GetAuth()) },
-		Client:      http.DefaultClient,
-		Registry:    c.getResolverOptions(),
+		Credentials: func(host string) (string, string, error) {
+			auth := r.GetAuth()
+			if auth == nil {
+				// Use the node-level credential if none is specified.
+				auth = c.getRegistryAuth(host)
+			}
+			return ParseAuth(auth)
+		},
+		Client:   http.DefaultClient,
+		Registry: c.getResolverOptions(),
 	})
-	_, desc, err := resolver.Resolve(ctx, ref)
+	name, desc, err := resolver.Resolve(ctx, ref)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to resolve image %q", ref)
 	}
@@ -100,20 +112,44 @@ func (c *criService) PullImage(ctx context.Context, r *runtime.PullImageRequest)
 	// image has already been converted.
 	isSchema1 := desc.MediaType == containerdimages.MediaTypeDockerSchema1Manifest
 
-	// TODO(mikebrow): add truncIndex for image id
-	image, err := c.client.Pull(ctx, ref,
+	pullOpts := []containerd.RemoteOpt{
 		containerd.WithSchema1Conversion,
 		containerd.WithResolver(resolver),
-	)
+	}
+	// Only pull the content of the selected platform.
+	platform, err := c.selectPullPlatform(ctx, resolver, name, desc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to select platform of image %q", ref)
+	}
+	if platform != "" {
+		pullOpts = append(pullOpts, containerd.WithPlatform(platform))
+	}
+	// TODO(mikebrow): add truncIndex for image id
+	cImage, err := c.client.Pull(ctx, ref, pullOpts...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to pull image %q", ref)
 	}
+	image, err := c.getPlatformImage(ctx, cImage)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to select platform of image %q", ref)
+	}
 
-	// Do best effort unpack.
-	logrus.Debugf("Unpack image %q", imageRef)
-	if err := image.Unpack(ctx, c.config.ContainerdConfig.Snapshotter); err != nil {
-		logrus.WithError(err).Warnf("Failed to unpack image %q", imageRef)
-		// Do not fail image pulling. Unpack will be retried before container creation.
+	// Do best effort unpack for the global snapshotter, and the snapshotter
+	// of the sandbox runtime if the sandbox config is specified.
+	snapshotters := []string{c.config.ContainerdConfig.Snapshotter}
+	if sandboxConfig := r.GetSandboxConfig(); sandboxConfig != nil {
+		if _, ociRuntime, err := c.getSandboxRuntime(sandboxConfig); err != nil {
+			logrus.WithError(err).Warnf("Failed to get sandbox runtime for image %q", imageRef)
+		} else if ociRuntime.Snapshotter != "" && ociRuntime.Snapshotter != snapshotters[0] {
+			snapshotters = append(snapshotters, ociRuntime.Snapshotter)
+		}
+	}
+	for _, snapshotter := range snapshotters {
+		logrus.Debugf("Unpack image %q for snapshotter %q", imageRef, snapshotter)
+		if err := image.Unpack(ctx, snapshotter); err != nil {
+			logrus.WithError(err).Warnf("Failed to unpack image %q for snapshotter %q", imageRef, snapshotter)
+			// Do not fail image pulling. Unpack will be retried before container creation.
+		}
 	}
 
 	// Get image information.
@@ -133,6 +169,13 @@ func (c *criService) PullImage(ctx context.Context, r *runtime.PullImageRequest)
 		}
 	}
 
+	// Record the pulled time on the image id reference, so that it survives
+	// restart.
+	pulledAt := time.Now()
+	if err := c.updateImageTimeLabel(ctx, imageID, imagePulledLabel, pulledAt); err != nil {
+		logrus.WithError(err).Warnf("Failed to update pulled time of image %q", imageID)
+	}
+
 	logrus.Debugf("Pulled image %q with image id %q, repo tag %q, repo digest %q", imageRef, imageID,
 		repoTag, repoDigest)
 	img := imagestore.Image{
@@ -141,6 +184,8 @@ func (c *criService) PullImage(ctx context.Context, r *runtime.PullImageRequest)
 		Size:      info.size,
 		ImageSpec: info.imagespec,
 		Image:     image,
+		Platform:  image.Platform(),
+		PulledAt:  pulledAt.UnixNano(),
 	}
 	if repoDigest != "" {
 		img.RepoDigests = []string{repoDigest}
@@ -161,6 +206,58 @@ func (c *criService) PullImage(ctx context.Context, r *runtime.PullImageRequest)
 	return &runtime.PullImageResponse{ImageRef: img.ID}, nil
 }
 
+// selectPullPlatform returns the first configured platform matching a manifest
+// in the manifest list of the image, so that only content of the platform is
+// pulled. Empty string is returned if the image is not a manifest list.
+func (c *criService) selectPullPlatform(ctx context.Context, resolver remotes.Resolver, name string,
+	desc imagespec.Descriptor) (string, error) {
+	switch desc.MediaType {
+	case containerdimages.MediaTypeDockerSchema2ManifestList, imagespec.MediaTypeImageIndex:
+	default:
+		return "", nil
+	}
+	fetcher, err := resolver.Fetcher(ctx, name)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get fetcher for %q", name)
+	}
+	rc, err := fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to fetch manifest list %q", desc.Digest)
+	}
+	defer rc.Close()
+	b, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read manifest list %q", desc.Digest)
+	}
+	var idx imagespec.Index
+	if err := json.Unmarshal(b, &idx); err != nil {
+		return "", errors.Wrapf(err, "failed to unmarshal manifest list %q", desc.Digest)
+	}
+	p, err := selectPlatform(idx.Manifests, c.config.ParsedPlatforms)
+	if err != nil {
+		return "", err
+	}
+	return platforms.Format(p), nil
+}
+
+// selectPlatform returns the first platform in the order of preference which
+// matches one of the manifests.
+func selectPlatform(manifests []imagespec.Descriptor, platformList []imagespec.Platform) (imagespec.Platform, error) {
+	for _, p := range platformList {
+		matcher := platforms.NewMatcher(p)
+		for _, m := range manifests {
+			if m.Platform == nil || matcher.Match(*m.Platform) {
+				return p, nil
+			}
+		}
+	}
+	var supported []string
+	for _, p := range platformList {
+		supported = append(supported, platforms.Format(p))
+	}
+	return imagespec.Platform{}, errors.Errorf("no manifest matches platforms %v", supported)
+}
+
 // ParseAuth parses AuthConfig and returns username and password/secret required by containerd.
 func ParseAuth(auth *runtime.AuthConfig) (string, string, error) {
 	if auth == nil {
@@ -212,6 +309,21 @@ func (c *criService) createImageReference(ctx context.Context, name string, desc
 	return err
 }
 
+// getRegistryAuth returns the node-level credential configured for the
+// registry host, or nil if none is configured.
+func (c *criService) getRegistryAuth(host string) *runtime.AuthConfig {
+	auth, ok := c.config.Registry.Auths[host]
+	if !ok {
+		return nil
+	}
+	return &runtime.AuthConfig{
+		Username:      auth.Username,
+		Password:      auth.Password,
+		Auth:          auth.Auth,
+		IdentityToken: auth.IdentityToken,
+	}
+}
+
 func (c *criService) getResolverOptions() map[string][]string {
 	options := make(map[string][]string)
 	for ns, mirror := range c.config.Mirrors {
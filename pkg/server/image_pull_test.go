@@ -20,6 +20,7 @@ import (
 	"encoding/base64"
 	"testing"
 
+	imagespec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/stretchr/testify/assert"
 	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
 )
@@ -72,3 +73,45 @@ func TestParseAuth(t *testing.T) {
 		assert.Equal(t, test.expectedSecret, s)
 	}
 }
+
+func TestSelectPlatform(t *testing.T) {
+	arm64 := imagespec.Platform{OS: "linux", Architecture: "arm64", Variant: "v8"}
+	armv7 := imagespec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}
+	armv6 := imagespec.Platform{OS: "linux", Architecture: "arm", Variant: "v6"}
+	amd64 := imagespec.Platform{OS: "linux", Architecture: "amd64"}
+	for desc, test := range map[string]struct {
+		manifests      []imagespec.Platform
+		platforms      []imagespec.Platform
+		expectErr      bool
+		expectPlatform imagespec.Platform
+	}{
+		"should select the first preferred platform": {
+			manifests:      []imagespec.Platform{amd64, armv7, arm64},
+			platforms:      []imagespec.Platform{arm64, armv7},
+			expectPlatform: arm64,
+		},
+		"should fall back to the next platform": {
+			manifests:      []imagespec.Platform{amd64, armv6, armv7},
+			platforms:      []imagespec.Platform{arm64, armv7},
+			expectPlatform: armv7,
+		},
+		"should not match a different variant": {
+			manifests: []imagespec.Platform{amd64, armv6},
+			platforms: []imagespec.Platform{arm64, armv7},
+			expectErr: true,
+		},
+	} {
+		t.Logf("TestCase %q", desc)
+		var manifests []imagespec.Descriptor
+		for i := range test.manifests {
+			manifests = append(manifests, imagespec.Descriptor{Platform: &test.manifests[i]})
+		}
+		p, err := selectPlatform(manifests, test.platforms)
+		if test.expectErr {
+			assert.Error(t, err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, test.expectPlatform, p)
+	}
+}
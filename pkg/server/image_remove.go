@@ -19,6 +19,7 @@ package server
 import (
 	"github.com/containerd/containerd/errdefs"
 	"github.com/containerd/containerd/images"
+	imagespec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
@@ -40,6 +41,9 @@ func (c *criService) RemoveImage(ctx context.Context, r *runtime.RemoveImageRequ
 		// return empty without error when image not found.
 		return &runtime.RemoveImageResponse{}, nil
 	}
+	if isPinnedImage(*image, c.getPinnedImages()) {
+		return nil, errors.Errorf("image %q is pinned", r.GetImage().GetImage())
+	}
 
 	// Exclude outdated image tag.
 	for i, tag := range image.RepoTags {
@@ -50,7 +54,11 @@ func (c *criService) RemoveImage(ctx context.Context, r *runtime.RemoveImageRequ
 			}
 			return nil, errors.Wrapf(err, "failed to get image %q", tag)
 		}
-		desc, err := cImage.Config(ctx)
+		var desc imagespec.Descriptor
+		pImage, err := c.getPlatformImage(ctx, cImage)
+		if err == nil {
+			desc, err = pImage.Config(ctx)
+		}
 		if err != nil {
 			// We can only get image id by reading Config from content.
 			// If the config is missing, we will fail to get image id,
@@ -18,13 +18,20 @@ package server
 
 import (
 	"encoding/json"
+	"sort"
 
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/platforms"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
 	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
 
+	ctrdutil "github.com/containerd/cri/pkg/containerd/util"
 	imagestore "github.com/containerd/cri/pkg/store/image"
+	"github.com/containerd/cri/pkg/util"
+	imagedigest "github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/identity"
 	imagespec "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
@@ -36,8 +43,9 @@ func (c *criService) ImageStatus(ctx context.Context, r *runtime.ImageStatusRequ
 	if err != nil {
 		return nil, errors.Wrapf(err, "can not resolve %q locally", r.GetImage().GetImage())
 	}
-	if image == nil {
-		// return empty without error when image not found.
+	if image == nil || image.Broken {
+		// return empty without error when image not found. Broken image
+		// is reported as not found, so that it is pulled again.
 		return &runtime.ImageStatusResponse{}, nil
 	}
 	// TODO(random-liu): [P0] Make sure corresponding snapshot exists. What if snapshot
@@ -74,8 +82,32 @@ func toCRIRuntimeImage(image *imagestore.Image) *runtime.Image {
 
 // TODO (mikebrow): discuss moving this struct and / or constants for info map for some or all of these fields to CRI
 type verboseImageInfo struct {
-	ChainID   string          `json:"chainID"`
-	ImageSpec imagespec.Image `json:"imageSpec"`
+	ChainID    string          `json:"chainID"`
+	ImageSpec  imagespec.Image `json:"imageSpec"`
+	PulledAt   int64           `json:"pulledAt,omitempty"`
+	LastUsedAt int64           `json:"lastUsedAt,omitempty"`
+	Pinned     bool            `json:"pinned"`
+	Platform   string          `json:"platform,omitempty"`
+	// ManifestDigest is the digest of the manifest of the selected platform.
+	ManifestDigest    string              `json:"manifestDigest,omitempty"`
+	ManifestMediaType string              `json:"manifestMediaType,omitempty"`
+	Layers            []verboseImageLayer `json:"layers,omitempty"`
+	// Snapshotters are the snapshotters the image is unpacked in.
+	Snapshotters []string `json:"snapshotters,omitempty"`
+	// Containers are ids of containers created from the image.
+	Containers []string `json:"containers,omitempty"`
+}
+
+// verboseImageLayer is the information of an image layer.
+type verboseImageLayer struct {
+	Digest    string `json:"digest"`
+	MediaType string `json:"mediaType"`
+	// Size is the compressed size of the layer.
+	Size   int64  `json:"size"`
+	DiffID string `json:"diffID"`
+	// UncompressedSize is the disk usage of the unpacked layer in each
+	// snapshotter, it is not set if the layer is not unpacked.
+	UncompressedSize map[string]int64 `json:"uncompressedSize,omitempty"`
 }
 
 // toCRIImageInfo converts internal image object information to CRI image status response info map.
@@ -87,8 +119,23 @@ func (c *criService) toCRIImageInfo(ctx context.Context, image *imagestore.Image
 	info := make(map[string]string)
 
 	imi := &verboseImageInfo{
-		ChainID:   image.ChainID,
-		ImageSpec: image.ImageSpec,
+		ChainID:    image.ChainID,
+		ImageSpec:  image.ImageSpec,
+		PulledAt:   image.PulledAt,
+		LastUsedAt: image.LastUsedAt,
+		Pinned:     isPinnedImage(*image, c.getPinnedImages()),
+		Containers: c.getImageContainers(image.ID),
+	}
+	if image.Platform.OS != "" {
+		imi.Platform = platforms.Format(image.Platform)
+	}
+	// The containerd image is not set for in-memory only image, e.g. in test.
+	if image.Image != nil {
+		// Content info is best effort, e.g. the manifest may be missing
+		// if image content is not verified.
+		if err := c.getImageContentInfo(ctx, image, imi); err != nil {
+			logrus.WithError(err).Errorf("Failed to get content info of image %q", image.ID)
+		}
 	}
 
 	m, err := json.Marshal(imi)
@@ -101,3 +148,85 @@ func (c *criService) toCRIImageInfo(ctx context.Context, image *imagestore.Image
 
 	return info, nil
 }
+
+// getImageContentInfo gets the manifest, layers and unpacked state of the
+// image from containerd.
+func (c *criService) getImageContentInfo(ctx context.Context, image *imagestore.Image, imi *verboseImageInfo) error {
+	cs := c.client.ContentStore()
+	desc, err := ctrdutil.ResolveManifest(ctx, cs, image.Image.Target(), c.config.ParsedPlatforms)
+	if err != nil {
+		return errors.Wrap(err, "failed to resolve manifest")
+	}
+	imi.ManifestDigest = desc.Digest.String()
+	imi.ManifestMediaType = desc.MediaType
+	b, err := content.ReadBlob(ctx, cs, desc.Digest)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read manifest %q", desc.Digest)
+	}
+	var manifest imagespec.Manifest
+	if err := json.Unmarshal(b, &manifest); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal manifest %q", desc.Digest)
+	}
+	diffIDs := image.ImageSpec.RootFS.DiffIDs
+	if len(diffIDs) != len(manifest.Layers) {
+		return errors.Errorf("mismatched image rootfs and manifest layers")
+	}
+	for i, l := range manifest.Layers {
+		imi.Layers = append(imi.Layers, verboseImageLayer{
+			Digest:    l.Digest.String(),
+			MediaType: l.MediaType,
+			Size:      l.Size,
+			DiffID:    diffIDs[i].String(),
+		})
+	}
+	chainIDs := identity.ChainIDs(append([]imagedigest.Digest{}, diffIDs...))
+	for _, snapshotter := range c.getImageSnapshotters(image.ID) {
+		unpacked, err := image.Image.IsUnpacked(ctx, snapshotter)
+		if err != nil {
+			logrus.WithError(err).Errorf("Failed to check whether image %q is unpacked in %q", image.ID, snapshotter)
+			continue
+		}
+		if !unpacked {
+			continue
+		}
+		imi.Snapshotters = append(imi.Snapshotters, snapshotter)
+		sn := c.client.SnapshotService(snapshotter)
+		for i := range imi.Layers {
+			// The layer snapshot is named with its chain id.
+			usage, err := sn.Usage(ctx, chainIDs[i].String())
+			if err != nil {
+				continue
+			}
+			if imi.Layers[i].UncompressedSize == nil {
+				imi.Layers[i].UncompressedSize = make(map[string]int64)
+			}
+			imi.Layers[i].UncompressedSize[snapshotter] = usage.Size
+		}
+	}
+	return nil
+}
+
+// getImageSnapshotters returns the snapshotters the image may be unpacked
+// in, including all snapshotters in use and snapshotters of containers
+// created from the image.
+func (c *criService) getImageSnapshotters(id string) []string {
+	snapshotters := c.getSnapshotters()
+	for _, cntr := range c.containerStore.List() {
+		if cntr.ImageRef == id && cntr.Snapshotter != "" && !util.InStringSlice(snapshotters, cntr.Snapshotter) {
+			snapshotters = append(snapshotters, cntr.Snapshotter)
+		}
+	}
+	return snapshotters
+}
+
+// getImageContainers returns ids of containers created from the image.
+func (c *criService) getImageContainers(id string) []string {
+	var containers []string
+	for _, cntr := range c.containerStore.List() {
+		if cntr.ImageRef == id {
+			containers = append(containers, cntr.ID)
+		}
+	}
+	sort.Strings(containers)
+	return containers
+}
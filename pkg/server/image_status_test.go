@@ -17,6 +17,7 @@ limitations under the License.
 package server
 
 import (
+	"encoding/json"
 	"testing"
 
 	imagespec "github.com/opencontainers/image-spec/specs-go/v1"
@@ -25,6 +26,8 @@ import (
 	"golang.org/x/net/context"
 	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
 
+	criconfig "github.com/containerd/cri/pkg/config"
+	containerstore "github.com/containerd/cri/pkg/store/container"
 	imagestore "github.com/containerd/cri/pkg/store/image"
 )
 
@@ -68,4 +71,61 @@ func TestImageStatus(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	assert.Equal(t, expected, resp.GetImage())
+
+	t.Logf("should return nil image spec without error for broken image")
+	require.NoError(t, c.imageStore.SetBroken(testID, true))
+	resp, err = c.ImageStatus(context.Background(), &runtime.ImageStatusRequest{
+		Image: &runtime.ImageSpec{Image: testID},
+	})
+	assert.NoError(t, err)
+	require.NotNil(t, resp)
+	assert.Nil(t, resp.GetImage())
+}
+
+func TestImageStatusVerboseContainers(t *testing.T) {
+	testID := "sha256:d848ce12891bf78792cda4a23c58984033b0c397a55e93a1556202222ecc5ed4"
+	c := newTestCRIService()
+	c.imageStore.Add(imagestore.Image{ID: testID, ChainID: "test-chain-id"})
+	for _, cntr := range []containerForTest{
+		{metadata: containerstore.Metadata{ID: "c2", ImageRef: testID}},
+		{metadata: containerstore.Metadata{ID: "c1", ImageRef: testID}},
+		{metadata: containerstore.Metadata{ID: "c3", ImageRef: "other-image"}},
+	} {
+		container, err := cntr.toContainer()
+		require.NoError(t, err)
+		require.NoError(t, c.containerStore.Add(container))
+	}
+
+	resp, err := c.ImageStatus(context.Background(), &runtime.ImageStatusRequest{
+		Image:   &runtime.ImageSpec{Image: testID},
+		Verbose: true,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp.GetImage())
+	var info verboseImageInfo
+	require.NoError(t, json.Unmarshal([]byte(resp.GetInfo()["info"]), &info))
+	assert.Equal(t, "test-chain-id", info.ChainID)
+	assert.Equal(t, []string{"c1", "c2"}, info.Containers)
+}
+
+func TestGetImageSnapshotters(t *testing.T) {
+	testID := "sha256:d848ce12891bf78792cda4a23c58984033b0c397a55e93a1556202222ecc5ed4"
+	c := newTestCRIService()
+	c.config.ContainerdConfig.Snapshotter = "overlayfs"
+	c.config.ContainerdConfig.Runtimes = map[string]criconfig.Runtime{
+		"runc":   {Snapshotter: "overlayfs"},
+		"kata":   {Snapshotter: "devmapper"},
+		"gvisor": {},
+	}
+	for _, cntr := range []containerForTest{
+		{metadata: containerstore.Metadata{ID: "c1", ImageRef: testID, Snapshotter: "overlayfs"}},
+		// The runtime snapshotter may be changed after the container is created.
+		{metadata: containerstore.Metadata{ID: "c2", ImageRef: testID, Snapshotter: "native"}},
+		{metadata: containerstore.Metadata{ID: "c3", ImageRef: "other-image", Snapshotter: "btrfs"}},
+	} {
+		container, err := cntr.toContainer()
+		require.NoError(t, err)
+		require.NoError(t, c.containerStore.Add(container))
+	}
+	assert.Equal(t, []string{"overlayfs", "devmapper", "native"}, c.getImageSnapshotters(testID))
 }
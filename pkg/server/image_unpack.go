@@ -0,0 +1,55 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"github.com/containerd/containerd/errdefs"
+	"github.com/sirupsen/logrus"
+
+	ctrdutil "github.com/containerd/cri/pkg/containerd/util"
+	"github.com/containerd/cri/pkg/store"
+)
+
+// unpackImages unpacks images which are not unpacked one by one. An image is
+// marked as broken if its content is missing, so that it is pulled again.
+func (c *criService) unpackImages(ids []string) {
+	ctx := ctrdutil.NamespacedContext()
+	snapshotter := c.config.ContainerdConfig.Snapshotter
+	for _, id := range ids {
+		image, err := c.imageStore.Get(id)
+		if err != nil {
+			if err != store.ErrNotExist {
+				logrus.WithError(err).Errorf("Failed to get image %q", id)
+			}
+			// The image may be removed in the meantime.
+			continue
+		}
+		logrus.Infof("Unpack image %q", id)
+		if err := image.Image.Unpack(ctx, snapshotter); err != nil {
+			if !errdefs.IsNotFound(err) {
+				logrus.WithError(err).Errorf("Failed to unpack image %q", id)
+				continue
+			}
+			logrus.WithError(err).Errorf("Image %q is broken, content is missing", id)
+			if err := c.imageStore.SetBroken(id, true); err != nil && err != store.ErrNotExist {
+				logrus.WithError(err).Errorf("Failed to mark image %q as broken", id)
+			}
+			continue
+		}
+		logrus.Infof("Unpacked image %q", id)
+	}
+}
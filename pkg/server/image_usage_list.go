@@ -0,0 +1,43 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"golang.org/x/net/context"
+
+	api "github.com/containerd/cri/pkg/api/v1"
+)
+
+// ListImageUsage lists all images with the time they were pulled and last used,
+// and whether they are pinned.
+func (c *criService) ListImageUsage(ctx context.Context, r *api.ListImageUsageRequest) (*api.ListImageUsageResponse, error) {
+	resp := &api.ListImageUsageResponse{}
+	pinned := c.getPinnedImages()
+	for _, image := range c.imageStore.List() {
+		resp.Images = append(resp.Images, &api.ImageUsage{
+			Id:          image.ID,
+			RepoTags:    image.RepoTags,
+			RepoDigests: image.RepoDigests,
+			Size_:       uint64(image.Size),
+			PulledAt:    image.PulledAt,
+			LastUsedAt:  image.LastUsedAt,
+			Pinned:      isPinnedImage(image, pinned),
+			Broken:      image.Broken,
+		})
+	}
+	return resp, nil
+}
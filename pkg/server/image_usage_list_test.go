@@ -0,0 +1,73 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+
+	api "github.com/containerd/cri/pkg/api/v1"
+	imagestore "github.com/containerd/cri/pkg/store/image"
+)
+
+func TestListImageUsage(t *testing.T) {
+	c := newTestCRIService()
+	c.config.PinnedImages = []string{"image-1"}
+	images := []imagestore.Image{
+		{
+			ID:          testImageID1,
+			RepoTags:    []string{"docker.io/library/image-1:latest"},
+			RepoDigests: []string{"docker.io/library/image-1@" + testImageID3},
+			Size:        10,
+			PulledAt:    100,
+			LastUsedAt:  200,
+		},
+		{
+			ID:       testImageID2,
+			RepoTags: []string{"docker.io/library/image-2:latest"},
+			Size:     20,
+		},
+	}
+	expect := []*api.ImageUsage{
+		{
+			Id:          testImageID1,
+			RepoTags:    []string{"docker.io/library/image-1:latest"},
+			RepoDigests: []string{"docker.io/library/image-1@" + testImageID3},
+			Size_:       10,
+			PulledAt:    100,
+			LastUsedAt:  200,
+			Pinned:      true,
+		},
+		{
+			Id:       testImageID2,
+			RepoTags: []string{"docker.io/library/image-2:latest"},
+			Size_:    20,
+		},
+	}
+	for _, image := range images {
+		require.NoError(t, c.imageStore.Add(image))
+	}
+	resp, err := c.ListImageUsage(context.Background(), &api.ListImageUsageRequest{})
+	require.NoError(t, err)
+	assert.Len(t, resp.GetImages(), len(expect))
+	for _, image := range expect {
+		assert.Contains(t, resp.GetImages(), image)
+	}
+}
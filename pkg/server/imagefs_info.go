@@ -24,28 +24,32 @@ import (
 	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
 )
 
-// ImageFsInfo returns information of the filesystem that is used to store images.
+// ImageFsInfo returns information of the filesystems that are used to store images,
+// one for each snapshotter in use.
 func (c *criService) ImageFsInfo(ctx context.Context, r *runtime.ImageFsInfoRequest) (*runtime.ImageFsInfoResponse, error) {
 	snapshots := c.snapshotStore.List()
-	timestamp := time.Now().UnixNano()
-	var usedBytes, inodesUsed uint64
-	for _, sn := range snapshots {
-		// Use the oldest timestamp as the timestamp of imagefs info.
-		if sn.Timestamp < timestamp {
-			timestamp = sn.Timestamp
+	resp := &runtime.ImageFsInfoResponse{}
+	for _, snapshotter := range c.getSnapshotters() {
+		timestamp := time.Now().UnixNano()
+		var usedBytes, inodesUsed uint64
+		for _, sn := range snapshots {
+			if sn.Snapshotter != snapshotter {
+				continue
+			}
+			// Use the oldest timestamp as the timestamp of imagefs info.
+			if sn.Timestamp < timestamp {
+				timestamp = sn.Timestamp
+			}
+			usedBytes += sn.Size
+			inodesUsed += sn.Inodes
 		}
-		usedBytes += sn.Size
-		inodesUsed += sn.Inodes
+		// TODO(random-liu): Handle content store
+		resp.ImageFilesystems = append(resp.ImageFilesystems, &runtime.FilesystemUsage{
+			Timestamp:  timestamp,
+			FsId:       &runtime.FilesystemIdentifier{Mountpoint: c.getImageFSPath(snapshotter)},
+			UsedBytes:  &runtime.UInt64Value{Value: usedBytes},
+			InodesUsed: &runtime.UInt64Value{Value: inodesUsed},
+		})
 	}
-	// TODO(random-liu): Handle content store
-	return &runtime.ImageFsInfoResponse{
-		ImageFilesystems: []*runtime.FilesystemUsage{
-			{
-				Timestamp:  timestamp,
-				FsId:       &runtime.FilesystemIdentifier{Mountpoint: c.imageFSPath},
-				UsedBytes:  &runtime.UInt64Value{Value: usedBytes},
-				InodesUsed: &runtime.UInt64Value{Value: inodesUsed},
-			},
-		},
-	}, nil
+	return resp, nil
 }
@@ -25,6 +25,7 @@ import (
 	"golang.org/x/net/context"
 	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
 
+	criconfig "github.com/containerd/cri/pkg/config"
 	snapshotstore "github.com/containerd/cri/pkg/store/snapshot"
 )
 
@@ -68,3 +69,37 @@ func TestImageFsInfo(t *testing.T) {
 	assert.Len(t, stats, 1)
 	assert.Equal(t, expected, stats[0])
 }
+
+func TestImageFsInfoMultipleSnapshotters(t *testing.T) {
+	c := newTestCRIService()
+	c.config.ContainerdConfig.Snapshotter = "overlayfs"
+	c.config.ContainerdRootDir = "/var/lib/containerd"
+	c.config.ContainerdConfig.Runtimes = map[string]criconfig.Runtime{
+		"runc": {Snapshotter: "overlayfs"},
+		"vm":   {Snapshotter: "devmapper"},
+	}
+	for _, sn := range []snapshotstore.Snapshot{
+		{Snapshotter: "overlayfs", Key: "key1", Size: 10, Inodes: 100, Timestamp: 234567},
+		{Snapshotter: "devmapper", Key: "key1", Size: 20, Inodes: 200, Timestamp: 123456},
+		{Snapshotter: "devmapper", Key: "key2", Size: 30, Inodes: 300, Timestamp: 345678},
+	} {
+		c.snapshotStore.Add(sn)
+	}
+	expected := []*runtime.FilesystemUsage{
+		{
+			Timestamp:  234567,
+			FsId:       &runtime.FilesystemIdentifier{Mountpoint: testImageFSPath},
+			UsedBytes:  &runtime.UInt64Value{Value: 10},
+			InodesUsed: &runtime.UInt64Value{Value: 100},
+		},
+		{
+			Timestamp:  123456,
+			FsId:       &runtime.FilesystemIdentifier{Mountpoint: "/var/lib/containerd/io.containerd.snapshotter.v1.devmapper"},
+			UsedBytes:  &runtime.UInt64Value{Value: 50},
+			InodesUsed: &runtime.UInt64Value{Value: 500},
+		},
+	}
+	resp, err := c.ImageFsInfo(context.Background(), &runtime.ImageFsInfoRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, expected, resp.GetImageFilesystems())
+}
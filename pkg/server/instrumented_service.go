@@ -463,6 +463,201 @@ func (in *instrumentedService) LoadImage(ctx context.Context, r *api.LoadImageRe
 	return in.c.LoadImage(ctrdutil.WithNamespace(ctx), r)
 }
 
+func (in *instrumentedService) LoadImageStream(stream api.CRIPluginService_LoadImageStreamServer) (err error) {
+	if err := in.checkInitialized(); err != nil {
+		return err
+	}
+	logrus.Debugf("LoadImageStream")
+	defer func() {
+		if err != nil {
+			logrus.WithError(err).Error("LoadImageStream failed")
+		} else {
+			logrus.Debugf("LoadImageStream succeeded")
+		}
+	}()
+	return in.c.LoadImageStream(&namespacedLoadImageStream{
+		CRIPluginService_LoadImageStreamServer: stream,
+		ctx:                                    ctrdutil.WithNamespace(stream.Context()),
+	})
+}
+
+// namespacedLoadImageStream overrides the context of the stream with
+// containerd namespace.
+type namespacedLoadImageStream struct {
+	api.CRIPluginService_LoadImageStreamServer
+	ctx context.Context
+}
+
+// Context returns the namespaced context.
+func (s *namespacedLoadImageStream) Context() context.Context {
+	return s.ctx
+}
+
+func (in *instrumentedService) ExportImage(r *api.ExportImageRequest, stream api.CRIPluginService_ExportImageServer) (err error) {
+	if err := in.checkInitialized(); err != nil {
+		return err
+	}
+	logrus.Debugf("ExportImage %v with format %v", r.GetImages(), r.GetFormat())
+	defer func() {
+		if err != nil {
+			logrus.WithError(err).Errorf("ExportImage %v failed", r.GetImages())
+		} else {
+			logrus.Debugf("ExportImage %v succeeded", r.GetImages())
+		}
+	}()
+	return in.c.ExportImage(r, &namespacedExportImageStream{
+		CRIPluginService_ExportImageServer: stream,
+		ctx:                                ctrdutil.WithNamespace(stream.Context()),
+	})
+}
+
+// namespacedExportImageStream overrides the context of the stream with
+// containerd namespace.
+type namespacedExportImageStream struct {
+	api.CRIPluginService_ExportImageServer
+	ctx context.Context
+}
+
+// Context returns the namespaced context.
+func (s *namespacedExportImageStream) Context() context.Context {
+	return s.ctx
+}
+
+func (in *instrumentedService) ContainerStatsDetailed(ctx context.Context, r *api.ContainerStatsDetailedRequest) (res *api.ContainerStatsDetailedResponse, err error) {
+	if err := in.checkInitialized(); err != nil {
+		return nil, err
+	}
+	log.Tracef("ContainerStatsDetailed with filter %+v", r.GetFilter())
+	defer func() {
+		if err != nil {
+			logrus.WithError(err).Error("ContainerStatsDetailed failed")
+		} else {
+			log.Tracef("ContainerStatsDetailed returns stats %+v", res.GetStats())
+		}
+	}()
+	return in.c.ContainerStatsDetailed(ctrdutil.WithNamespace(ctx), r)
+}
+
+func (in *instrumentedService) ListPodSandboxStats(ctx context.Context, r *api.ListPodSandboxStatsRequest) (res *api.ListPodSandboxStatsResponse, err error) {
+	if err := in.checkInitialized(); err != nil {
+		return nil, err
+	}
+	log.Tracef("ListPodSandboxStats with filter %+v", r.GetFilter())
+	defer func() {
+		if err != nil {
+			logrus.WithError(err).Error("ListPodSandboxStats failed")
+		} else {
+			log.Tracef("ListPodSandboxStats returns stats %+v", res.GetStats())
+		}
+	}()
+	return in.c.ListPodSandboxStats(ctrdutil.WithNamespace(ctx), r)
+}
+
+func (in *instrumentedService) ListImageUsage(ctx context.Context, r *api.ListImageUsageRequest) (res *api.ListImageUsageResponse, err error) {
+	if err := in.checkInitialized(); err != nil {
+		return nil, err
+	}
+	log.Tracef("ListImageUsage")
+	defer func() {
+		if err != nil {
+			logrus.WithError(err).Error("ListImageUsage failed")
+		} else {
+			log.Tracef("ListImageUsage returns images %+v", res.GetImages())
+		}
+	}()
+	return in.c.ListImageUsage(ctrdutil.WithNamespace(ctx), r)
+}
+
+func (in *instrumentedService) CheckpointContainer(ctx context.Context, r *api.CheckpointContainerRequest) (res *api.CheckpointContainerResponse, err error) {
+	if err := in.checkInitialized(); err != nil {
+		return nil, err
+	}
+	logrus.Infof("CheckpointContainer for %q to %q", r.GetContainerId(), r.GetPath())
+	defer func() {
+		if err != nil {
+			logrus.WithError(err).Errorf("CheckpointContainer for %q failed", r.GetContainerId())
+		} else {
+			logrus.Infof("CheckpointContainer for %q returns successfully", r.GetContainerId())
+		}
+	}()
+	return in.c.CheckpointContainer(ctrdutil.WithNamespace(ctx), r)
+}
+
+func (in *instrumentedService) RestoreContainer(ctx context.Context, r *api.RestoreContainerRequest) (res *api.RestoreContainerResponse, err error) {
+	if err := in.checkInitialized(); err != nil {
+		return nil, err
+	}
+	logrus.Infof("RestoreContainer in sandbox %q from %q", r.GetPodSandboxId(), r.GetPath())
+	defer func() {
+		if err != nil {
+			logrus.WithError(err).Errorf("RestoreContainer in sandbox %q failed", r.GetPodSandboxId())
+		} else {
+			logrus.Infof("RestoreContainer in sandbox %q returns container id %q", r.GetPodSandboxId(), res.GetContainerId())
+		}
+	}()
+	return in.c.RestoreContainer(ctrdutil.WithNamespace(ctx), r)
+}
+
+func (in *instrumentedService) PauseContainer(ctx context.Context, r *api.PauseContainerRequest) (res *api.PauseContainerResponse, err error) {
+	if err := in.checkInitialized(); err != nil {
+		return nil, err
+	}
+	logrus.Infof("PauseContainer for %q", r.GetContainerId())
+	defer func() {
+		if err != nil {
+			logrus.WithError(err).Errorf("PauseContainer for %q failed", r.GetContainerId())
+		} else {
+			logrus.Infof("PauseContainer for %q returns successfully", r.GetContainerId())
+		}
+	}()
+	return in.c.PauseContainer(ctrdutil.WithNamespace(ctx), r)
+}
+
+func (in *instrumentedService) ResumeContainer(ctx context.Context, r *api.ResumeContainerRequest) (res *api.ResumeContainerResponse, err error) {
+	if err := in.checkInitialized(); err != nil {
+		return nil, err
+	}
+	logrus.Infof("ResumeContainer for %q", r.GetContainerId())
+	defer func() {
+		if err != nil {
+			logrus.WithError(err).Errorf("ResumeContainer for %q failed", r.GetContainerId())
+		} else {
+			logrus.Infof("ResumeContainer for %q returns successfully", r.GetContainerId())
+		}
+	}()
+	return in.c.ResumeContainer(ctrdutil.WithNamespace(ctx), r)
+}
+
+func (in *instrumentedService) PausePodSandbox(ctx context.Context, r *api.PausePodSandboxRequest) (res *api.PausePodSandboxResponse, err error) {
+	if err := in.checkInitialized(); err != nil {
+		return nil, err
+	}
+	logrus.Infof("PausePodSandbox for %q", r.GetPodSandboxId())
+	defer func() {
+		if err != nil {
+			logrus.WithError(err).Errorf("PausePodSandbox for %q failed", r.GetPodSandboxId())
+		} else {
+			logrus.Infof("PausePodSandbox for %q returns successfully", r.GetPodSandboxId())
+		}
+	}()
+	return in.c.PausePodSandbox(ctrdutil.WithNamespace(ctx), r)
+}
+
+func (in *instrumentedService) ResumePodSandbox(ctx context.Context, r *api.ResumePodSandboxRequest) (res *api.ResumePodSandboxResponse, err error) {
+	if err := in.checkInitialized(); err != nil {
+		return nil, err
+	}
+	logrus.Infof("ResumePodSandbox for %q", r.GetPodSandboxId())
+	defer func() {
+		if err != nil {
+			logrus.WithError(err).Errorf("ResumePodSandbox for %q failed", r.GetPodSandboxId())
+		} else {
+			logrus.Infof("ResumePodSandbox for %q returns successfully", r.GetPodSandboxId())
+		}
+	}()
+	return in.c.ResumePodSandbox(ctrdutil.WithNamespace(ctx), r)
+}
+
 func (in *instrumentedService) ReopenContainerLog(ctx context.Context, r *runtime.ReopenContainerLogRequest) (res *runtime.ReopenContainerLogResponse, err error) {
 	if err := in.checkInitialized(); err != nil {
 		return nil, err
@@ -26,7 +26,6 @@ import (
 	containerdio "github.com/containerd/containerd/cio"
 	"github.com/containerd/containerd/errdefs"
 	containerdimages "github.com/containerd/containerd/images"
-	"github.com/containerd/containerd/platforms"
 	"github.com/containerd/typeurl"
 	"github.com/docker/distribution/reference"
 	"github.com/docker/docker/pkg/system"
@@ -35,6 +34,7 @@ import (
 	"golang.org/x/net/context"
 	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
 
+	ctrdutil "github.com/containerd/cri/pkg/containerd/util"
 	cio "github.com/containerd/cri/pkg/server/io"
 	containerstore "github.com/containerd/cri/pkg/store/container"
 	imagestore "github.com/containerd/cri/pkg/store/image"
@@ -64,11 +64,8 @@ func (c *criService) recover(ctx context.Context) error {
 			continue
 		}
 		logrus.Debugf("Loaded sandbox %+v", sb)
-		if err := c.sandboxStore.Add(sb); err != nil {
-			return errors.Wrapf(err, "failed to add sandbox %q to store", sandbox.ID())
-		}
-		if err := c.sandboxNameIndex.Reserve(sb.Name, sb.ID); err != nil {
-			return errors.Wrapf(err, "failed to reserve sandbox name %q", sb.Name)
+		if err := c.addRecoveredSandbox(sb); err != nil {
+			return err
 		}
 	}
 
@@ -99,16 +96,37 @@ func (c *criService) recover(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to list images")
 	}
-	images, err := loadImages(ctx, cImages, c.config.ContainerdConfig.Snapshotter)
+	var pImages []*ctrdutil.PlatformImage
+	for _, i := range cImages {
+		pImage, err := c.getPlatformImage(ctx, i)
+		if err != nil {
+			logrus.WithError(err).Warnf("Failed to select platform of image %q", i.Name())
+			continue
+		}
+		pImages = append(pImages, pImage)
+	}
+	images, unpack, err := loadImages(ctx, pImages, c.config.ContainerdConfig.Snapshotter,
+		c.config.VerifyImageContent)
 	if err != nil {
 		return errors.Wrap(err, "failed to load images")
 	}
 	for _, image := range images {
+		// Recover image pulled and last used time from labels of the image
+		// id reference.
+		if cImage, err := c.client.ImageService().Get(ctx, image.ID); err == nil {
+			image.PulledAt, image.LastUsedAt = getImageTimesFromLabels(cImage.Labels)
+		} else {
+			logrus.WithError(err).Warnf("Failed to get image id reference %q", image.ID)
+		}
 		logrus.Debugf("Loaded image %+v", image)
 		if err := c.imageStore.Add(image); err != nil {
 			return errors.Wrapf(err, "failed to add image %q to store", image.ID)
 		}
 	}
+	// Unpack images in the background, so that the startup is not blocked.
+	if len(unpack) > 0 {
+		go c.unpackImages(unpack)
+	}
 
 	// It's possible that containerd containers are deleted unexpectedly. In that case,
 	// we can't even get metadata, we should cleanup orphaned sandbox/container directories
@@ -133,6 +151,28 @@ func (c *criService) recover(ctx context.Context) error {
 	return nil
 }
 
+// addRecoveredSandbox reserves the resources of a recovered sandbox and adds
+// it into the sandbox store. The user namespace id range of the sandbox is
+// reserved, so that it is not allocated to other sandboxes. Recovery fails if
+// the range overlaps with another sandbox, because containers in them would
+// share the same host ids.
+func (c *criService) addRecoveredSandbox(sb sandboxstore.Sandbox) error {
+	if userns := sb.UserNamespace; userns != nil {
+		if c.usernsAllocator == nil {
+			logrus.Warnf("Sandbox %q runs in user namespace, but userns remap range is not configured", sb.ID)
+		} else if err := c.usernsAllocator.Reserve(sb.ID, userns.HostID, userns.Size); err != nil {
+			return errors.Wrapf(err, "failed to reserve user namespace id range of sandbox %q", sb.ID)
+		}
+	}
+	if err := c.sandboxStore.Add(sb); err != nil {
+		return errors.Wrapf(err, "failed to add sandbox %q to store", sb.ID)
+	}
+	if err := c.sandboxNameIndex.Reserve(sb.Name, sb.ID); err != nil {
+		return errors.Wrapf(err, "failed to reserve sandbox name %q", sb.Name)
+	}
+	return nil
+}
+
 // loadContainer loads container from containerd and status checkpoint.
 func loadContainer(ctx context.Context, cntr containerd.Container, containerDir, volatileContainerDir string) (containerstore.Container, error) {
 	id := cntr.ID()
@@ -151,6 +191,15 @@ func loadContainer(ctx context.Context, cntr containerd.Container, containerDir,
 		return container, errors.Wrapf(err, "failed to unmarshal metadata extension %q", ext)
 	}
 	meta := data.(*containerstore.Metadata)
+	// The snapshotter is not recorded for containers created before
+	// per-runtime snapshotters are supported.
+	if meta.Snapshotter == "" {
+		info, err := cntr.Info(ctx)
+		if err != nil {
+			return container, errors.Wrap(err, "failed to get container info")
+		}
+		meta.Snapshotter = info.Snapshotter
+	}
 
 	// Load status from checkpoint.
 	status, err := containerstore.LoadStatus(containerDir, id)
@@ -231,9 +280,18 @@ func loadContainer(ctx context.Context, cntr containerd.Container, containerDir,
 			if status.State() != runtime.ContainerState_CONTAINER_CREATED {
 				return container, errors.Errorf("unexpected container state for created task: %q", status.State())
 			}
-		case containerd.Running:
+		case containerd.Running, containerd.Paused, containerd.Pausing:
 			// Task is running. Container must be in `RUNNING` state, based on our assuption that
 			// "task should not be started when containerd is down".
+			// The task may have been paused or resumed when the status is checkpointed, always
+			// use the paused state of the task.
+			if paused := s.Status != containerd.Running; paused != status.Paused {
+				status.Paused = paused
+				status.PausedAt = 0
+				if paused {
+					status.PausedAt = time.Now().UnixNano()
+				}
+			}
 			switch status.State() {
 			case runtime.ContainerState_CONTAINER_EXITED:
 				return container, errors.Errorf("unexpected container state for running task: %q", status.State())
@@ -307,6 +365,11 @@ func loadSandbox(ctx context.Context, cntr containerd.Container) (sandboxstore.S
 		return sandbox, errors.Wrap(err, "failed to get sandbox container info")
 	}
 	createdAt := info.CreatedAt
+	// The snapshotter is not recorded for sandboxes created before
+	// per-runtime snapshotters are supported.
+	if meta.Snapshotter == "" {
+		meta.Snapshotter = info.Snapshotter
+	}
 
 	// Load sandbox status.
 	t, err := cntr.Task(ctx, nil)
@@ -378,13 +441,14 @@ func loadSandbox(ctx context.Context, cntr containerd.Container) (sandboxstore.S
 	return sandbox, nil
 }
 
-// loadImages loads images from containerd.
-// TODO(random-liu): Check whether image is unpacked, because containerd put image reference
-// into store before image is unpacked.
-func loadImages(ctx context.Context, cImages []containerd.Image,
-	snapshotter string) ([]imagestore.Image, error) {
+// loadImages loads images from containerd. Contents of images are verified
+// if verifyContent is true, images with missing contents are marked as broken.
+// It also returns ids of images which are not unpacked, e.g. the unpacking is
+// interrupted or the snapshot is removed.
+func loadImages(ctx context.Context, cImages []*ctrdutil.PlatformImage,
+	snapshotter string, verifyContent bool) ([]imagestore.Image, []string, error) {
 	// Group images by image id.
-	imageMap := make(map[string][]containerd.Image)
+	imageMap := make(map[string][]*ctrdutil.PlatformImage)
 	for _, i := range cImages {
 		desc, err := i.Config(ctx)
 		if err != nil {
@@ -394,29 +458,38 @@ func loadImages(ctx context.Context, cImages []containerd.Image,
 		id := desc.Digest.String()
 		imageMap[id] = append(imageMap[id], i)
 	}
-	var images []imagestore.Image
+	var (
+		images []imagestore.Image
+		unpack []string
+	)
 	for id, imgs := range imageMap {
 		// imgs len must be > 0, or else the entry will not be created in
 		// previous loop.
 		i := imgs[0]
-		ok, _, _, _, err := containerdimages.Check(ctx, i.ContentStore(), i.Target(), platforms.Default())
-		if err != nil {
-			logrus.WithError(err).Errorf("Failed to check image content readiness for %q", i.Name())
-			continue
-		}
-		if !ok {
-			logrus.Warnf("The image content readiness for %q is not ok", i.Name())
-			continue
-		}
-		// Checking existence of top-level snapshot for each image being recovered.
-		unpacked, err := i.IsUnpacked(ctx, snapshotter)
-		if err != nil {
-			logrus.WithError(err).Warnf("Failed to Check whether image is unpacked for image %s", i.Name())
-			continue
+		broken := false
+		if verifyContent {
+			// The platform is already selected, check the selected manifest only.
+			ok, _, _, missing, err := containerdimages.Check(ctx, i.ContentStore(), i.Manifest(), "")
+			if err != nil {
+				logrus.WithError(err).Errorf("Failed to check image content readiness for %q", i.Name())
+				continue
+			}
+			if !ok {
+				logrus.Warnf("The image %q is broken, missing contents %v", i.Name(), missing)
+				broken = true
+			}
 		}
-		if !unpacked {
-			logrus.Warnf("The image %s is not unpacked.", i.Name())
-			// TODO(random-liu): Consider whether we should try unpack here.
+		if !broken {
+			// Checking existence of top-level snapshot for each image being recovered.
+			unpacked, err := i.IsUnpacked(ctx, snapshotter)
+			if err != nil {
+				logrus.WithError(err).Warnf("Failed to Check whether image is unpacked for image %s", i.Name())
+				continue
+			}
+			if !unpacked {
+				logrus.Warnf("The image %s is not unpacked, it will be unpacked in the background", i.Name())
+				unpack = append(unpack, id)
+			}
 		}
 
 		info, err := getImageInfo(ctx, i)
@@ -430,6 +503,8 @@ func loadImages(ctx context.Context, cImages []containerd.Image,
 			Size:      info.size,
 			ImageSpec: info.imagespec,
 			Image:     i,
+			Platform:  i.Platform(),
+			Broken:    broken,
 		}
 		// Recover repo digests and repo tags.
 		for _, i := range imgs {
@@ -452,7 +527,7 @@ func loadImages(ctx context.Context, cImages []containerd.Image,
 		}
 		images = append(images, image)
 	}
-	return images, nil
+	return images, unpack, nil
 }
 
 func cleanupOrphanedSandboxDirs(cntrs []containerd.Container, sandboxesRoot string) error {
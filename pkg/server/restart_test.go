@@ -0,0 +1,86 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sandboxstore "github.com/containerd/cri/pkg/store/sandbox"
+	"github.com/containerd/cri/pkg/userns"
+)
+
+func TestAddRecoveredSandbox(t *testing.T) {
+	const (
+		start   = 100000
+		podSize = 65536
+	)
+	newSandbox := func(id string, ns *sandboxstore.UserNamespace) sandboxstore.Sandbox {
+		return sandboxstore.NewSandbox(
+			sandboxstore.Metadata{ID: id, Name: id + "-name", UserNamespace: ns},
+			sandboxstore.Status{State: sandboxstore.StateReady},
+		)
+	}
+	c := newTestCRIService()
+	var err error
+	c.usernsAllocator, err = userns.NewAllocator(start, podSize*4, podSize)
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		desc      string
+		sandbox   sandboxstore.Sandbox
+		expectErr bool
+	}{
+		{
+			desc:    "should recover sandbox with user namespace",
+			sandbox: newSandbox("sandbox-1", &sandboxstore.UserNamespace{HostID: start, Size: podSize}),
+		},
+		{
+			desc:    "should recover sandbox with user namespace allocated with a different pod size",
+			sandbox: newSandbox("sandbox-2", &sandboxstore.UserNamespace{HostID: start + podSize, Size: podSize / 2}),
+		},
+		{
+			desc:      "should fail to recover sandbox with range overlapping with another sandbox",
+			sandbox:   newSandbox("sandbox-3", &sandboxstore.UserNamespace{HostID: start + podSize/2, Size: podSize}),
+			expectErr: true,
+		},
+		{
+			desc:    "should recover sandbox without user namespace",
+			sandbox: newSandbox("sandbox-4", nil),
+		},
+	} {
+		t.Logf("TestCase %q", test.desc)
+		err := c.addRecoveredSandbox(test.sandbox)
+		_, getErr := c.sandboxStore.Get(test.sandbox.ID)
+		if test.expectErr {
+			assert.Error(t, err)
+			assert.Error(t, getErr, "sandbox should not be added")
+			assert.NoError(t, c.sandboxNameIndex.Reserve(test.sandbox.Name, test.sandbox.ID),
+				"sandbox name should not be reserved")
+			continue
+		}
+		assert.NoError(t, err)
+		assert.NoError(t, getErr)
+	}
+
+	t.Logf("should not allocate ranges of recovered sandboxes")
+	id, err := c.usernsAllocator.Allocate("sandbox-5")
+	require.NoError(t, err)
+	assert.EqualValues(t, start+podSize*2, id)
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2018 The Containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
+
+	api "github.com/containerd/cri/pkg/api/v1"
+	ctrdutil "github.com/containerd/cri/pkg/containerd/util"
+	containerstore "github.com/containerd/cri/pkg/store/container"
+)
+
+// PausePodSandbox pauses all running containers in the sandbox. The sandbox
+// container itself is not paused, so that the sandbox stays ready. If any
+// container fails to be paused, containers paused by the call are resumed.
+func (c *criService) PausePodSandbox(ctx context.Context, r *api.PausePodSandboxRequest) (_ *api.PausePodSandboxResponse, retErr error) {
+	sandbox, err := c.sandboxStore.Get(r.GetPodSandboxId())
+	if err != nil {
+		return nil, errors.Wrapf(err, "an error occurred when try to find sandbox %q",
+			r.GetPodSandboxId())
+	}
+	id := sandbox.ID
+
+	var paused []containerstore.Container
+	defer func() {
+		if retErr != nil {
+			deferCtx, deferCancel := ctrdutil.DeferContext()
+			defer deferCancel()
+			for _, container := range paused {
+				if err := c.resumeContainer(deferCtx, container); err != nil {
+					logrus.WithError(err).Errorf("Failed to resume container %q", container.ID)
+				}
+			}
+		}
+	}()
+	for _, container := range c.containerStore.List() {
+		if container.SandboxID != id {
+			continue
+		}
+		status := container.Status.Get()
+		if status.State() != runtime.ContainerState_CONTAINER_RUNNING || status.Paused {
+			continue
+		}
+		if err := c.pauseContainer(ctx, container); err != nil {
+			return nil, errors.Wrapf(err, "failed to pause container %q", container.ID)
+		}
+		paused = append(paused, container)
+	}
+	return &api.PausePodSandboxResponse{}, nil
+}
@@ -102,5 +102,10 @@ func (c *criService) RemovePodSandbox(ctx context.Context, r *runtime.RemovePodS
 	// Release the sandbox name reserved for the sandbox.
 	c.sandboxNameIndex.ReleaseByKey(id)
 
+	// Release the user namespace id range allocated to the sandbox.
+	if sandbox.UserNamespace != nil && c.usernsAllocator != nil {
+		c.usernsAllocator.Release(id)
+	}
+
 	return &runtime.RemovePodSandboxResponse{}, nil
 }
@@ -0,0 +1,44 @@
+/*
+Copyright 2018 The Containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+
+	api "github.com/containerd/cri/pkg/api/v1"
+)
+
+// ResumePodSandbox resumes all paused containers in the sandbox.
+func (c *criService) ResumePodSandbox(ctx context.Context, r *api.ResumePodSandboxRequest) (*api.ResumePodSandboxResponse, error) {
+	sandbox, err := c.sandboxStore.Get(r.GetPodSandboxId())
+	if err != nil {
+		return nil, errors.Wrapf(err, "an error occurred when try to find sandbox %q",
+			r.GetPodSandboxId())
+	}
+	id := sandbox.ID
+
+	for _, container := range c.containerStore.List() {
+		if container.SandboxID != id {
+			continue
+		}
+		if err := c.resumeContainer(ctx, container); err != nil {
+			return nil, errors.Wrapf(err, "failed to resume container %q", container.ID)
+		}
+	}
+	return &api.ResumePodSandboxResponse{}, nil
+}
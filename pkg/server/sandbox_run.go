@@ -19,17 +19,21 @@ package server
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/containerd/containerd"
 	containerdio "github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/containers"
 	"github.com/containerd/containerd/errdefs"
 	"github.com/containerd/containerd/linux/runctypes"
 	"github.com/containerd/containerd/oci"
 	cni "github.com/containerd/go-cni"
 	"github.com/containerd/typeurl"
+	"github.com/gogo/protobuf/types"
 	imagespec "github.com/opencontainers/image-spec/specs-go/v1"
 	runtimespec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/opencontainers/selinux/go-selinux/label"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
@@ -83,15 +87,48 @@ func (c *criService) RunPodSandbox(ctx context.Context, r *runtime.RunPodSandbox
 		},
 	)
 
+	runtimeHandler, ociRuntime, err := c.getSandboxRuntime(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get sandbox runtime")
+	}
+	sandbox.RuntimeHandler = runtimeHandler
+	sandbox.Snapshotter = ociRuntime.Snapshotter
+	logrus.Debugf("Use OCI runtime %q %+v for sandbox %q", runtimeHandler, ociRuntime, id)
+
+	// Allocate user namespace ID range for the sandbox.
+	userns, err := c.sandboxUserNamespaceEnabled(config, ociRuntime)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get sandbox user namespace option")
+	}
+	if userns {
+		hostID, err := c.usernsAllocator.Allocate(id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to allocate user namespace id range for sandbox %q", id)
+		}
+		defer func() {
+			if retErr != nil {
+				c.usernsAllocator.Release(id)
+			}
+		}()
+		sandbox.UserNamespace = &sandboxstore.UserNamespace{
+			HostID: hostID,
+			Size:   c.usernsAllocator.Size(),
+		}
+		logrus.Debugf("Allocated user namespace id range %+v for sandbox %q", sandbox.UserNamespace, id)
+	}
+
 	// Ensure sandbox container image snapshot.
-	image, err := c.ensureImageExists(ctx, c.config.SandboxImage)
+	image, err := c.ensureImageExists(ctx, ociRuntime.SandboxImage, ociRuntime.Snapshotter)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to get sandbox image %q", c.config.SandboxImage)
+		return nil, errors.Wrapf(err, "failed to get sandbox image %q", ociRuntime.SandboxImage)
 	}
 	securityContext := config.GetLinux().GetSecurityContext()
 	//Create Network Namespace if it is not in host network
 	hostNet := securityContext.GetNamespaceOptions().GetNetwork() == runtime.NamespaceMode_NODE
-	if !hostNet {
+	// The network namespace of a sandbox in user namespace must be owned by
+	// the user namespace, so it is created by the runtime with the sandbox
+	// task instead, see below.
+	if !hostNet && sandbox.UserNamespace == nil {
 		// If it is not in host network namespace then create a namespace and set the sandbox
 		// handle. NetNSPath in sandbox metadata and NetNS is non empty only for non host network
 		// namespaces. If the pod is in host network namespace then both are empty and should not
@@ -131,17 +168,14 @@ func (c *criService) RunPodSandbox(ctx context.Context, r *runtime.RunPodSandbox
 		}()
 	}
 
-	ociRuntime, err := c.getSandboxRuntime(config)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to get sandbox runtime")
-	}
-	logrus.Debugf("Use OCI %+v for sandbox %q", ociRuntime, id)
-
 	// Create sandbox container.
-	spec, err := c.generateSandboxContainerSpec(id, config, &image.ImageSpec.Config, sandbox.NetNSPath)
+	spec, err := c.generateSandboxContainerSpec(id, config, ociRuntime, &image.ImageSpec.Config, sandbox.NetNSPath)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to generate sandbox container spec")
 	}
+	if hooks := c.ociHooks.apply(spec, config.GetAnnotations(), false); len(hooks) > 0 {
+		logrus.Debugf("Injected OCI hooks %v into sandbox %q", hooks, id)
+	}
 	logrus.Debugf("Sandbox container spec: %+v", spec)
 
 	var specOpts []oci.SpecOpts
@@ -152,7 +186,8 @@ func (c *criService) RunPodSandbox(ctx context.Context, r *runtime.RunPodSandbox
 	seccompSpecOpts, err := generateSeccompSpecOpts(
 		securityContext.GetSeccompProfilePath(),
 		securityContext.GetPrivileged(),
-		c.seccompEnabled)
+		c.seccompEnabled,
+		c.seccompProfiles)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to generate seccomp spec opts")
 	}
@@ -160,11 +195,17 @@ func (c *criService) RunPodSandbox(ctx context.Context, r *runtime.RunPodSandbox
 		specOpts = append(specOpts, seccompSpecOpts)
 	}
 
+	snapshotOpt := customopts.WithNewSnapshot(id, image.Image)
+	if userns := sandbox.UserNamespace; userns != nil {
+		specOpts = append(specOpts, oci.WithUserNamespace(0, userns.HostID, userns.Size))
+		snapshotOpt = customopts.WithRemappedSnapshot(id, image.Image, userns.HostID, userns.HostID, userns.Size)
+	}
+
 	sandboxLabels := buildLabels(config.Labels, containerKindSandbox)
 
 	opts := []containerd.NewContainerOpts{
-		containerd.WithSnapshotter(c.config.ContainerdConfig.Snapshotter),
-		customopts.WithNewSnapshot(id, image.Image),
+		containerd.WithSnapshotter(ociRuntime.Snapshotter),
+		snapshotOpt,
 		containerd.WithSpec(spec, specOpts...),
 		containerd.WithContainerLabels(sandboxLabels),
 		containerd.WithContainerExtension(sandboxMetadataExtension, &sandbox.Metadata),
@@ -173,7 +214,8 @@ func (c *criService) RunPodSandbox(ctx context.Context, r *runtime.RunPodSandbox
 			&runctypes.RuncOptions{
 				Runtime:       ociRuntime.Engine,
 				RuntimeRoot:   ociRuntime.Root,
-				SystemdCgroup: c.config.SystemdCgroup})} // TODO (mikebrow): add CriuPath when we add support for pause
+				SystemdCgroup: ociRuntime.SystemdCgroup,
+				CriuPath:      ociRuntime.CriuPath})}
 
 	container, err := c.client.NewContainer(ctx, id, opts...)
 	if err != nil {
@@ -220,7 +262,7 @@ func (c *criService) RunPodSandbox(ctx context.Context, r *runtime.RunPodSandbox
 	}()
 
 	// Setup sandbox /dev/shm, /etc/hosts and /etc/resolv.conf.
-	if err = c.setupSandboxFiles(id, config); err != nil {
+	if err = c.setupSandboxFiles(id, config, spec.Linux.MountLabel); err != nil {
 		return nil, errors.Wrapf(err, "failed to setup sandbox files")
 	}
 	defer func() {
@@ -244,6 +286,56 @@ func (c *criService) RunPodSandbox(ctx context.Context, r *runtime.RunPodSandbox
 		return nil, errors.Wrap(err, "failed to update sandbox created timestamp")
 	}
 
+	// Create the sandbox task before it is added into the store, so that the
+	// network namespace created by the runtime in the sandbox user namespace
+	// can be set up before the sandbox becomes visible. The task is started
+	// in the transaction below.
+	var task containerd.Task
+	if !hostNet && sandbox.UserNamespace != nil {
+		task, err = container.NewTask(ctx, containerdio.NullIO)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create containerd task")
+		}
+		defer func() {
+			if retErr != nil {
+				deferCtx, deferCancel := ctrdutil.DeferContext()
+				defer deferCancel()
+				if _, err := task.Delete(deferCtx, containerd.WithProcessKill); err != nil && !errdefs.IsNotFound(err) {
+					logrus.WithError(err).Errorf("Failed to delete sandbox container %q", id)
+				}
+			}
+		}()
+		sandbox.NetNS, err = sandboxstore.NewNetNSFromPID(task.Pid())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create network namespace for sandbox %q", id)
+		}
+		sandbox.NetNSPath = sandbox.NetNS.GetPath()
+		defer func() {
+			if retErr != nil {
+				if err := sandbox.NetNS.Remove(); err != nil {
+					logrus.WithError(err).Errorf("Failed to remove network namespace %s for sandbox %q", sandbox.NetNSPath, id)
+				}
+				sandbox.NetNSPath = ""
+			}
+		}()
+		sandbox.IP, err = c.setupPod(id, sandbox.NetNSPath, config)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to setup network for sandbox %q", id)
+		}
+		defer func() {
+			if retErr != nil {
+				if err := c.teardownPod(id, sandbox.NetNSPath, config); err != nil {
+					logrus.WithError(err).Errorf("Failed to destroy network for sandbox %q", id)
+				}
+			}
+		}()
+		// Checkpoint the network namespace path and IP, which are not known
+		// when the sandbox container is created.
+		if err := c.updateSandboxMetadata(ctx, &sandbox.Metadata); err != nil {
+			return nil, errors.Wrapf(err, "failed to update metadata of sandbox %q", id)
+		}
+	}
+
 	// Add sandbox into sandbox store in UNKNOWN state.
 	sandbox.Container = container
 	if err := c.sandboxStore.Add(sandbox); err != nil {
@@ -282,25 +374,28 @@ func (c *criService) RunPodSandbox(ctx context.Context, r *runtime.RunPodSandbox
 		// Given so, we should keep the sandbox in UNKNOWN state if `Update` fails,
 		// and ignore sandbox in UNKNOWN state in all the inspection functions.
 
-		// Create sandbox task in containerd.
-		log.Tracef("Create sandbox container (id=%q, name=%q).",
-			id, name)
-		// We don't need stdio for sandbox container.
-		task, err := container.NewTask(ctx, containerdio.NullIO)
-		if err != nil {
-			return status, errors.Wrap(err, "failed to create containerd task")
-		}
-		defer func() {
-			if retErr != nil {
-				deferCtx, deferCancel := ctrdutil.DeferContext()
-				defer deferCancel()
-				// Cleanup the sandbox container if an error is returned.
-				// It's possible that task is deleted by event monitor.
-				if _, err := task.Delete(deferCtx, containerd.WithProcessKill); err != nil && !errdefs.IsNotFound(err) {
-					logrus.WithError(err).Errorf("Failed to delete sandbox container %q", id)
-				}
+		// Create sandbox task in containerd if it is not created yet.
+		if task == nil {
+			log.Tracef("Create sandbox container (id=%q, name=%q).",
+				id, name)
+			// We don't need stdio for sandbox container.
+			t, err := container.NewTask(ctx, containerdio.NullIO)
+			if err != nil {
+				return status, errors.Wrap(err, "failed to create containerd task")
 			}
-		}()
+			defer func() {
+				if retErr != nil {
+					deferCtx, deferCancel := ctrdutil.DeferContext()
+					defer deferCancel()
+					// Cleanup the sandbox container if an error is returned.
+					// It's possible that task is deleted by event monitor.
+					if _, err := t.Delete(deferCtx, containerd.WithProcessKill); err != nil && !errdefs.IsNotFound(err) {
+						logrus.WithError(err).Errorf("Failed to delete sandbox container %q", id)
+					}
+				}
+			}()
+			task = t
+		}
 
 		if err := task.Start(ctx); err != nil {
 			return status, errors.Wrapf(err, "failed to start sandbox container task %q", id)
@@ -314,10 +409,12 @@ func (c *criService) RunPodSandbox(ctx context.Context, r *runtime.RunPodSandbox
 		return nil, errors.Wrap(err, "failed to start sandbox container")
 	}
 
+	c.markImageUsed(ctx, image)
+
 	return &runtime.RunPodSandboxResponse{PodSandboxId: id}, nil
 }
 
-func (c *criService) generateSandboxContainerSpec(id string, config *runtime.PodSandboxConfig,
+func (c *criService) generateSandboxContainerSpec(id string, config *runtime.PodSandboxConfig, ociRuntime criconfig.Runtime,
 	imageConfig *imagespec.ImageConfig, nsPath string) (*runtimespec.Spec, error) {
 	// Creates a spec Generator with the default spec.
 	// TODO(random-liu): [P1] Compare the default settings with docker and containerd default.
@@ -357,7 +454,7 @@ func (c *criService) generateSandboxContainerSpec(id string, config *runtime.Pod
 	// Set cgroups parent.
 	if config.GetLinux().GetCgroupParent() != "" {
 		cgroupsPath := getCgroupsPath(config.GetLinux().GetCgroupParent(), id,
-			c.config.SystemdCgroup)
+			ociRuntime.SystemdCgroup)
 		g.SetLinuxCgroupsPath(cgroupsPath)
 	}
 	// When cgroup parent is not set, containerd-shim will create container in a child cgroup
@@ -411,13 +508,17 @@ func (c *criService) generateSandboxContainerSpec(id string, config *runtime.Pod
 }
 
 // setupSandboxFiles sets up necessary sandbox files including /dev/shm, /etc/hosts
-// and /etc/resolv.conf.
-func (c *criService) setupSandboxFiles(id string, config *runtime.PodSandboxConfig) error {
+// and /etc/resolv.conf. The files are labeled with the sandbox mount label, so that
+// only containers in the sandbox, which share the sandbox level, can access them.
+func (c *criService) setupSandboxFiles(id string, config *runtime.PodSandboxConfig, mountLabel string) error {
 	// TODO(random-liu): Consider whether we should maintain /etc/hosts and /etc/resolv.conf in kubelet.
 	sandboxEtcHosts := c.getSandboxHosts(id)
 	if err := c.os.CopyFile(etcHosts, sandboxEtcHosts, 0644); err != nil {
 		return errors.Wrapf(err, "failed to generate sandbox hosts file %q", sandboxEtcHosts)
 	}
+	if err := relabelPath(sandboxEtcHosts, mountLabel, false); err != nil {
+		return err
+	}
 
 	// Set DNS options. Maintain a resolv.conf for the sandbox.
 	var err error
@@ -441,6 +542,9 @@ func (c *criService) setupSandboxFiles(id string, config *runtime.PodSandboxConf
 			return errors.Wrapf(err, "failed to write resolv content to %q", resolvPath)
 		}
 	}
+	if err := relabelPath(resolvPath, mountLabel, false); err != nil {
+		return err
+	}
 
 	// Setup sandbox /dev/shm.
 	if config.GetLinux().GetSecurityContext().GetNamespaceOptions().GetIpc() == runtime.NamespaceMode_NODE {
@@ -452,7 +556,7 @@ func (c *criService) setupSandboxFiles(id string, config *runtime.PodSandboxConf
 		if err := c.os.MkdirAll(sandboxDevShm, 0700); err != nil {
 			return errors.Wrap(err, "failed to create sandbox shm")
 		}
-		shmproperty := fmt.Sprintf("mode=1777,size=%d", defaultShmSize)
+		shmproperty := label.FormatMountLabel(fmt.Sprintf("mode=1777,size=%d", defaultShmSize), mountLabel)
 		if err := c.os.Mount("shm", sandboxDevShm, "tmpfs", uintptr(unix.MS_NOEXEC|unix.MS_NOSUID|unix.MS_NODEV), shmproperty); err != nil {
 			return errors.Wrap(err, "failed to mount sandbox shm")
 		}
@@ -461,6 +565,22 @@ func (c *criService) setupSandboxFiles(id string, config *runtime.PodSandboxConf
 	return nil
 }
 
+// updateSandboxMetadata updates the sandbox metadata checkpointed in the
+// containerd container extension.
+func (c *criService) updateSandboxMetadata(ctx context.Context, meta *sandboxstore.Metadata) error {
+	any, err := typeurl.MarshalAny(meta)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal sandbox metadata")
+	}
+	if _, err := c.client.ContainerService().Update(ctx, containers.Container{
+		ID:         meta.ID,
+		Extensions: map[string]types.Any{sandboxMetadataExtension: *any},
+	}, "extensions."+sandboxMetadataExtension); err != nil {
+		return errors.Wrap(err, "failed to update containerd container extension")
+	}
+	return nil
+}
+
 // parseDNSOptions parse DNS options into resolv.conf format content,
 // if none option is specified, will return empty with no error.
 func parseDNSOptions(servers, searches, options []string) (string, error) {
@@ -563,24 +683,54 @@ func hostPrivilegedSandbox(config *runtime.PodSandboxConfig) bool {
 	return false
 }
 
-// getSandboxRuntime returns the runtime configuration for sandbox.
-// If the sandbox contains untrusted workload, runtime for untrusted workload will be returned,
-// or else default runtime will be returned.
-func (c *criService) getSandboxRuntime(config *runtime.PodSandboxConfig) (criconfig.Runtime, error) {
-	untrusted := false
+// sandboxUserNamespaceEnabled returns true if the sandbox runs in a user
+// namespace. The user namespace annotation overrides the default of the
+// runtime. Sandboxes with host privilege don't run in a user namespace by
+// default, and it is an error to enable it for them with the annotation.
+func (c *criService) sandboxUserNamespaceEnabled(config *runtime.PodSandboxConfig, r criconfig.Runtime) (bool, error) {
+	enabled := r.UserNamespace && !hostPrivilegedSandbox(config)
+	if v, ok := config.GetAnnotations()[annotations.UserNamespace]; ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return false, errors.Wrapf(err, "invalid user namespace annotation %q", v)
+		}
+		if b && hostPrivilegedSandbox(config) {
+			return false, errors.New("user namespace is not supported for sandbox with host privilege")
+		}
+		enabled = b
+	}
+	if enabled && c.usernsAllocator == nil {
+		return false, errors.New("userns remap range is not configured")
+	}
+	return enabled, nil
+}
+
+// getSandboxRuntime returns the runtime handler name and runtime configuration
+// for sandbox. The runtime handler is selected by the runtime handler annotation.
+// If the sandbox contains untrusted workload, runtime for untrusted workload will
+// be returned, or else default runtime will be returned if the annotation is not set.
+func (c *criService) getSandboxRuntime(config *runtime.PodSandboxConfig) (string, criconfig.Runtime, error) {
+	handler := config.GetAnnotations()[annotations.RuntimeHandler]
 	if untrustedWorkload(config) {
 		// TODO(random-liu): Figure out we should return error or not.
 		if hostPrivilegedSandbox(config) {
-			return criconfig.Runtime{}, errors.New("untrusted workload with host privilege is not allowed")
+			return "", criconfig.Runtime{}, errors.New("untrusted workload with host privilege is not allowed")
+		}
+		if handler != "" && handler != criconfig.RuntimeUntrusted {
+			return "", criconfig.Runtime{}, errors.Errorf("untrusted workload can not run on runtime %q", handler)
 		}
-		untrusted = true
+		handler = criconfig.RuntimeUntrusted
+	}
+	if handler == "" {
+		handler = c.config.ContainerdConfig.DefaultRuntimeName
 	}
 
-	if untrusted {
-		if c.config.ContainerdConfig.UntrustedWorkloadRuntime.Type == "" {
-			return criconfig.Runtime{}, errors.New("no runtime for untrusted workload is configured")
-		}
-		return c.config.ContainerdConfig.UntrustedWorkloadRuntime, nil
+	r, ok := c.config.ContainerdConfig.Runtimes[handler]
+	if !ok {
+		return "", criconfig.Runtime{}, errors.Errorf("no runtime for %q is configured", handler)
+	}
+	if r.PrivilegedWorkload == criconfig.PrivilegedWorkloadDeny && hostPrivilegedSandbox(config) {
+		return "", criconfig.Runtime{}, errors.Errorf("workload with host privilege is not allowed on runtime %q", handler)
 	}
-	return c.config.ContainerdConfig.DefaultRuntime, nil
+	return handler, r, nil
 }
@@ -33,6 +33,7 @@ import (
 	criconfig "github.com/containerd/cri/pkg/config"
 	ostesting "github.com/containerd/cri/pkg/os/testing"
 	sandboxstore "github.com/containerd/cri/pkg/store/sandbox"
+	"github.com/containerd/cri/pkg/userns"
 )
 
 func getRunPodSandboxTestData() (*runtime.PodSandboxConfig, *imagespec.ImageConfig, func(*testing.T, string, *runtimespec.Spec)) {
@@ -162,7 +163,7 @@ func TestGenerateSandboxContainerSpec(t *testing.T) {
 		if test.imageConfigChange != nil {
 			test.imageConfigChange(imageConfig)
 		}
-		spec, err := c.generateSandboxContainerSpec(testID, config, imageConfig, nsPath)
+		spec, err := c.generateSandboxContainerSpec(testID, config, criconfig.Runtime{}, imageConfig, nsPath)
 		if test.expectErr {
 			assert.Error(t, err)
 			assert.Nil(t, spec)
@@ -286,7 +287,7 @@ options timeout:1
 				},
 			},
 		}
-		c.setupSandboxFiles(testID, cfg)
+		c.setupSandboxFiles(testID, cfg, "")
 		calls := c.os.(*ostesting.FakeOS).GetCalls()
 		assert.Len(t, calls, len(test.expectedCalls))
 		for i, expected := range test.expectedCalls {
@@ -495,38 +496,53 @@ func TestHostPrivilegedSandbox(t *testing.T) {
 
 func TestGetSandboxRuntime(t *testing.T) {
 	untrustedWorkloadRuntime := criconfig.Runtime{
-		Type:   "io.containerd.runtime.v1.linux",
-		Engine: "untursted-workload-runtime",
-		Root:   "",
+		Type:               "io.containerd.runtime.v1.linux",
+		Engine:             "untursted-workload-runtime",
+		Root:               "",
+		PrivilegedWorkload: criconfig.PrivilegedWorkloadAllow,
 	}
 
 	defaultRuntime := criconfig.Runtime{
-		Type:   "io.containerd.runtime.v1.linux",
-		Engine: "default-runtime",
-		Root:   "",
+		Type:               "io.containerd.runtime.v1.linux",
+		Engine:             "default-runtime",
+		Root:               "",
+		PrivilegedWorkload: criconfig.PrivilegedWorkloadAllow,
+	}
+
+	vmRuntime := criconfig.Runtime{
+		Type:               "io.containerd.runtime.v1.linux",
+		Engine:             "vm-runtime",
+		Root:               "",
+		PrivilegedWorkload: criconfig.PrivilegedWorkloadDeny,
+	}
+
+	privilegedSandboxConfig := func(annotations map[string]string) *runtime.PodSandboxConfig {
+		return &runtime.PodSandboxConfig{
+			Linux: &runtime.LinuxPodSandboxConfig{
+				SecurityContext: &runtime.LinuxSandboxSecurityContext{
+					Privileged: true,
+				},
+			},
+			Annotations: annotations,
+		}
 	}
 
 	for desc, test := range map[string]struct {
-		sandboxConfig            *runtime.PodSandboxConfig
-		defaultRuntime           criconfig.Runtime
-		untrustedWorkloadRuntime criconfig.Runtime
-		expectErr                bool
-		expectedRuntime          criconfig.Runtime
+		sandboxConfig   *runtime.PodSandboxConfig
+		runtimes        map[string]criconfig.Runtime
+		expectErr       bool
+		expectedHandler string
+		expectedRuntime criconfig.Runtime
 	}{
 		"should return error if untrusted workload requires host privilege": {
-			sandboxConfig: &runtime.PodSandboxConfig{
-				Linux: &runtime.LinuxPodSandboxConfig{
-					SecurityContext: &runtime.LinuxSandboxSecurityContext{
-						Privileged: true,
-					},
-				},
-				Annotations: map[string]string{
-					annotations.UntrustedWorkload: "true",
-				},
+			sandboxConfig: privilegedSandboxConfig(map[string]string{
+				annotations.UntrustedWorkload: "true",
+			}),
+			runtimes: map[string]criconfig.Runtime{
+				criconfig.RuntimeDefault:   defaultRuntime,
+				criconfig.RuntimeUntrusted: untrustedWorkloadRuntime,
 			},
-			defaultRuntime:           defaultRuntime,
-			untrustedWorkloadRuntime: untrustedWorkloadRuntime,
-			expectErr:                true,
+			expectErr: true,
 		},
 		"should use untrusted workload runtime for untrusted workload": {
 			sandboxConfig: &runtime.PodSandboxConfig{
@@ -534,15 +550,21 @@ func TestGetSandboxRuntime(t *testing.T) {
 					annotations.UntrustedWorkload: "true",
 				},
 			},
-			defaultRuntime:           defaultRuntime,
-			untrustedWorkloadRuntime: untrustedWorkloadRuntime,
-			expectedRuntime:          untrustedWorkloadRuntime,
+			runtimes: map[string]criconfig.Runtime{
+				criconfig.RuntimeDefault:   defaultRuntime,
+				criconfig.RuntimeUntrusted: untrustedWorkloadRuntime,
+			},
+			expectedHandler: criconfig.RuntimeUntrusted,
+			expectedRuntime: untrustedWorkloadRuntime,
 		},
 		"should use default runtime for regular workload": {
-			sandboxConfig:            &runtime.PodSandboxConfig{},
-			defaultRuntime:           defaultRuntime,
-			untrustedWorkloadRuntime: untrustedWorkloadRuntime,
-			expectedRuntime:          defaultRuntime,
+			sandboxConfig: &runtime.PodSandboxConfig{},
+			runtimes: map[string]criconfig.Runtime{
+				criconfig.RuntimeDefault:   defaultRuntime,
+				criconfig.RuntimeUntrusted: untrustedWorkloadRuntime,
+			},
+			expectedHandler: criconfig.RuntimeDefault,
+			expectedRuntime: defaultRuntime,
 		},
 		"should use default runtime for trusted workload": {
 			sandboxConfig: &runtime.PodSandboxConfig{
@@ -550,9 +572,12 @@ func TestGetSandboxRuntime(t *testing.T) {
 					annotations.UntrustedWorkload: "false",
 				},
 			},
-			defaultRuntime:           defaultRuntime,
-			untrustedWorkloadRuntime: untrustedWorkloadRuntime,
-			expectedRuntime:          defaultRuntime,
+			runtimes: map[string]criconfig.Runtime{
+				criconfig.RuntimeDefault:   defaultRuntime,
+				criconfig.RuntimeUntrusted: untrustedWorkloadRuntime,
+			},
+			expectedHandler: criconfig.RuntimeDefault,
+			expectedRuntime: defaultRuntime,
 		},
 		"should return error if untrusted workload runtime is required but not configured": {
 			sandboxConfig: &runtime.PodSandboxConfig{
@@ -560,8 +585,57 @@ func TestGetSandboxRuntime(t *testing.T) {
 					annotations.UntrustedWorkload: "true",
 				},
 			},
-			defaultRuntime: defaultRuntime,
-			expectErr:      true,
+			runtimes: map[string]criconfig.Runtime{
+				criconfig.RuntimeDefault: defaultRuntime,
+			},
+			expectErr: true,
+		},
+		"should use runtime selected by runtime handler annotation": {
+			sandboxConfig: &runtime.PodSandboxConfig{
+				Annotations: map[string]string{
+					annotations.RuntimeHandler: "vm",
+				},
+			},
+			runtimes: map[string]criconfig.Runtime{
+				criconfig.RuntimeDefault: defaultRuntime,
+				"vm":                     vmRuntime,
+			},
+			expectedHandler: "vm",
+			expectedRuntime: vmRuntime,
+		},
+		"should return error if selected runtime is not configured": {
+			sandboxConfig: &runtime.PodSandboxConfig{
+				Annotations: map[string]string{
+					annotations.RuntimeHandler: "vm",
+				},
+			},
+			runtimes: map[string]criconfig.Runtime{
+				criconfig.RuntimeDefault: defaultRuntime,
+			},
+			expectErr: true,
+		},
+		"should return error if selected runtime denies host privilege": {
+			sandboxConfig: privilegedSandboxConfig(map[string]string{
+				annotations.RuntimeHandler: "vm",
+			}),
+			runtimes: map[string]criconfig.Runtime{
+				criconfig.RuntimeDefault: defaultRuntime,
+				"vm":                     vmRuntime,
+			},
+			expectErr: true,
+		},
+		"should return error if untrusted workload selects other runtime": {
+			sandboxConfig: &runtime.PodSandboxConfig{
+				Annotations: map[string]string{
+					annotations.UntrustedWorkload: "true",
+					annotations.RuntimeHandler:    criconfig.RuntimeDefault,
+				},
+			},
+			runtimes: map[string]criconfig.Runtime{
+				criconfig.RuntimeDefault:   defaultRuntime,
+				criconfig.RuntimeUntrusted: untrustedWorkloadRuntime,
+			},
+			expectErr: true,
 		},
 	} {
 		t.Run(desc, func(t *testing.T) {
@@ -569,14 +643,89 @@ func TestGetSandboxRuntime(t *testing.T) {
 			cri.config = criconfig.Config{
 				PluginConfig: criconfig.DefaultConfig(),
 			}
-			cri.config.ContainerdConfig.DefaultRuntime = test.defaultRuntime
-			cri.config.ContainerdConfig.UntrustedWorkloadRuntime = test.untrustedWorkloadRuntime
-			r, err := cri.getSandboxRuntime(test.sandboxConfig)
+			cri.config.ContainerdConfig.Runtimes = test.runtimes
+			handler, r, err := cri.getSandboxRuntime(test.sandboxConfig)
 			assert.Equal(t, test.expectErr, err != nil)
+			assert.Equal(t, test.expectedHandler, handler)
 			assert.Equal(t, test.expectedRuntime, r)
 		})
 	}
 }
 
+func TestSandboxUserNamespaceEnabled(t *testing.T) {
+	hostNetConfig := func(annotations map[string]string) *runtime.PodSandboxConfig {
+		return &runtime.PodSandboxConfig{
+			Linux: &runtime.LinuxPodSandboxConfig{
+				SecurityContext: &runtime.LinuxSandboxSecurityContext{
+					NamespaceOptions: &runtime.NamespaceOption{
+						Network: runtime.NamespaceMode_NODE,
+					},
+				},
+			},
+			Annotations: annotations,
+		}
+	}
+	for desc, test := range map[string]struct {
+		sandboxConfig  *runtime.PodSandboxConfig
+		runtimeUserns  bool
+		noAllocator    bool
+		expectErr      bool
+		expectedUserns bool
+	}{
+		"should use runtime default": {
+			sandboxConfig:  &runtime.PodSandboxConfig{},
+			runtimeUserns:  true,
+			expectedUserns: true,
+		},
+		"should not enable user namespace by default": {
+			sandboxConfig: &runtime.PodSandboxConfig{},
+		},
+		"annotation should override runtime default": {
+			sandboxConfig: &runtime.PodSandboxConfig{
+				Annotations: map[string]string{annotations.UserNamespace: "false"},
+			},
+			runtimeUserns: true,
+		},
+		"annotation should enable user namespace": {
+			sandboxConfig: &runtime.PodSandboxConfig{
+				Annotations: map[string]string{annotations.UserNamespace: "true"},
+			},
+			expectedUserns: true,
+		},
+		"should return error for invalid annotation": {
+			sandboxConfig: &runtime.PodSandboxConfig{
+				Annotations: map[string]string{annotations.UserNamespace: "invalid"},
+			},
+			expectErr: true,
+		},
+		"should not use runtime default for sandbox with host privilege": {
+			sandboxConfig: hostNetConfig(nil),
+			runtimeUserns: true,
+		},
+		"should return error if annotation enables user namespace for sandbox with host privilege": {
+			sandboxConfig: hostNetConfig(map[string]string{annotations.UserNamespace: "true"}),
+			expectErr:     true,
+		},
+		"should return error if userns remap range is not configured": {
+			sandboxConfig: &runtime.PodSandboxConfig{},
+			runtimeUserns: true,
+			noAllocator:   true,
+			expectErr:     true,
+		},
+	} {
+		t.Logf("TestCase %q", desc)
+		c := newTestCRIService()
+		if !test.noAllocator {
+			var err error
+			c.usernsAllocator, err = userns.NewAllocator(100000, 65536, 65536)
+			require.NoError(t, err)
+		}
+		enabled, err := c.sandboxUserNamespaceEnabled(test.sandboxConfig,
+			criconfig.Runtime{UserNamespace: test.runtimeUserns})
+		assert.Equal(t, test.expectErr, err != nil)
+		assert.Equal(t, test.expectedUserns, enabled)
+	}
+}
+
 // TODO(random-liu): [P1] Add unit test for different error cases to make sure
 // the function cleans up on error properly.
@@ -0,0 +1,144 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"time"
+
+	"github.com/containerd/cgroups"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	api "github.com/containerd/cri/pkg/api/v1"
+	sandboxstore "github.com/containerd/cri/pkg/store/sandbox"
+	snapshotstore "github.com/containerd/cri/pkg/store/snapshot"
+)
+
+// ListPodSandboxStats returns stats of all pod sandboxes matching the filter.
+func (c *criService) ListPodSandboxStats(ctx context.Context, r *api.ListPodSandboxStatsRequest) (*api.ListPodSandboxStatsResponse, error) {
+	resp := &api.ListPodSandboxStatsResponse{}
+	for _, sb := range c.filterSandboxesForStats(r.GetFilter()) {
+		stats, err := c.getPodSandboxStats(ctx, sb)
+		if err != nil {
+			// Do not fail the whole list, the sandbox may be torn down
+			// in the middle.
+			logrus.WithError(err).Errorf("Failed to get stats for sandbox %q", sb.ID)
+			continue
+		}
+		resp.Stats = append(resp.Stats, stats)
+	}
+	return resp, nil
+}
+
+// filterSandboxesForStats returns sandboxes matching the stats filter.
+func (c *criService) filterSandboxesForStats(filter *api.PodSandboxStatsFilter) []sandboxstore.Sandbox {
+	id := filter.GetId()
+	if sb, err := c.sandboxStore.Get(id); err == nil {
+		id = sb.ID
+	}
+	var sandboxes []sandboxstore.Sandbox
+	for _, sb := range c.sandboxStore.List() {
+		if id != "" && sb.ID != id {
+			continue
+		}
+		if filter.GetLabelSelector() != nil &&
+			!matchLabelSelector(filter.GetLabelSelector(), sb.Config.GetLabels()) {
+			continue
+		}
+		sandboxes = append(sandboxes, sb)
+	}
+	return sandboxes
+}
+
+// getPodSandboxStats collects stats of a pod sandbox.
+func (c *criService) getPodSandboxStats(ctx context.Context, sb sandboxstore.Sandbox) (*api.PodSandboxStats, error) {
+	stats := &api.PodSandboxStats{
+		Id:            sb.ID,
+		Labels:        sb.Config.GetLabels(),
+		Annotations:   sb.Config.GetAnnotations(),
+		WritableLayer: c.getPodWritableLayerUsage(sb),
+	}
+	// Stats are only available for ready sandbox, because the pod cgroup
+	// and network namespace may have been cleaned up after the sandbox
+	// is stopped.
+	if sb.Status.Get().State != sandboxstore.StateReady {
+		return stats, nil
+	}
+	if parent := sb.Config.GetLinux().GetCgroupParent(); parent != "" {
+		ociRuntime, err := c.getSandboxRuntimeConfig(ctx, sb)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get sandbox runtime")
+		}
+		cgroupsPath, err := getPodCgroupsPath(parent, ociRuntime.SystemdCgroup)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get pod cgroups path from %q", parent)
+		}
+		metrics, err := getCgroupMetrics(cgroupsPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get cgroup metrics for %q", cgroupsPath)
+		}
+		stats.Timestamp = time.Now().UnixNano()
+		stats.Metrics = metrics
+	}
+	network, err := getSandboxNetworkStats(sb)
+	if err != nil {
+		// Network stats are best effort, the sandbox may be stopped
+		// in the middle.
+		logrus.WithError(err).Debugf("Failed to get network stats for sandbox %q", sb.ID)
+	}
+	stats.Network = network
+	return stats, nil
+}
+
+// getPodWritableLayerUsage sums up the cached writable layer usage of the
+// sandbox container and all containers in the sandbox.
+func (c *criService) getPodWritableLayerUsage(sb sandboxstore.Sandbox) *api.WritableLayerUsage {
+	keys := []snapshotstore.Snapshot{{Snapshotter: sb.Snapshotter, Key: sb.ID}}
+	for _, cntr := range c.containerStore.List() {
+		if cntr.SandboxID == sb.ID {
+			keys = append(keys, snapshotstore.Snapshot{Snapshotter: cntr.Snapshotter, Key: cntr.ID})
+		}
+	}
+	usage := &api.WritableLayerUsage{}
+	for _, key := range keys {
+		sn, err := c.snapshotStore.Get(key.Snapshotter, key.Key)
+		if err != nil {
+			// The snapshot stats may not be collected yet.
+			continue
+		}
+		if usage.Timestamp == 0 || sn.Timestamp < usage.Timestamp {
+			usage.Timestamp = sn.Timestamp
+		}
+		usage.UsedBytes += sn.Size
+		usage.InodesUsed += sn.Inodes
+	}
+	return usage
+}
+
+// getCgroupMetrics loads the cgroup of the given path and collects its metrics.
+func getCgroupMetrics(cgroupsPath string) (*cgroups.Metrics, error) {
+	cg, err := cgroups.Load(cgroups.V1, cgroups.StaticPath(cgroupsPath))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load cgroup")
+	}
+	metrics, err := cg.Stat(cgroups.IgnoreNotExist)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to stat cgroup")
+	}
+	return metrics, nil
+}
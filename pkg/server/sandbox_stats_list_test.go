@@ -0,0 +1,128 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"testing"
+
+	snapshot "github.com/containerd/containerd/snapshots"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
+
+	api "github.com/containerd/cri/pkg/api/v1"
+	containerstore "github.com/containerd/cri/pkg/store/container"
+	sandboxstore "github.com/containerd/cri/pkg/store/sandbox"
+	snapshotstore "github.com/containerd/cri/pkg/store/snapshot"
+)
+
+func TestListPodSandboxStats(t *testing.T) {
+	c := newTestCRIService()
+	// Use not ready sandboxes, so that cgroup and network stats
+	// are not collected from the host.
+	for _, sb := range []sandboxstore.Sandbox{
+		sandboxstore.NewSandbox(
+			sandboxstore.Metadata{
+				ID:     "s-1abcdef1234",
+				Config: &runtime.PodSandboxConfig{Labels: map[string]string{"a": "b"}},
+			},
+			sandboxstore.Status{State: sandboxstore.StateNotReady},
+		),
+		sandboxstore.NewSandbox(
+			sandboxstore.Metadata{
+				ID:     "s-2abcdef1234",
+				Config: &runtime.PodSandboxConfig{Labels: map[string]string{"c": "d"}},
+			},
+			sandboxstore.Status{State: sandboxstore.StateNotReady},
+		),
+		// Ready sandbox with an unknown runtime handler fails to get stats.
+		sandboxstore.NewSandbox(
+			sandboxstore.Metadata{
+				ID: "s-3abcdef1234",
+				Config: &runtime.PodSandboxConfig{
+					Linux: &runtime.LinuxPodSandboxConfig{CgroupParent: "/test-cgroup-parent"},
+				},
+				RuntimeHandler: "unknown",
+			},
+			sandboxstore.Status{State: sandboxstore.StateReady},
+		),
+	} {
+		assert.NoError(t, c.sandboxStore.Add(sb))
+	}
+	for _, cntr := range []containerForTest{
+		{metadata: containerstore.Metadata{ID: "c-1container", SandboxID: "s-1abcdef1234"}},
+		{metadata: containerstore.Metadata{ID: "c-2container", SandboxID: "s-1abcdef1234"}},
+		{metadata: containerstore.Metadata{ID: "c-3container", SandboxID: "s-2abcdef1234"}},
+	} {
+		container, err := cntr.toContainer()
+		assert.NoError(t, err)
+		assert.NoError(t, c.containerStore.Add(container))
+	}
+	for _, sn := range []snapshotstore.Snapshot{
+		{Key: "s-1abcdef1234", Kind: snapshot.KindActive, Size: 1, Inodes: 10, Timestamp: 300},
+		{Key: "c-1container", Kind: snapshot.KindActive, Size: 2, Inodes: 20, Timestamp: 100},
+		{Key: "c-2container", Kind: snapshot.KindActive, Size: 4, Inodes: 40, Timestamp: 200},
+		{Key: "c-3container", Kind: snapshot.KindActive, Size: 8, Inodes: 80, Timestamp: 400},
+	} {
+		c.snapshotStore.Add(sn)
+	}
+	for desc, test := range map[string]struct {
+		filter *api.PodSandboxStatsFilter
+		expect []*api.PodSandboxStats
+	}{
+		"should filter by truncated id": {
+			filter: &api.PodSandboxStatsFilter{Id: "s-1"},
+			expect: []*api.PodSandboxStats{
+				{
+					Id:     "s-1abcdef1234",
+					Labels: map[string]string{"a": "b"},
+					WritableLayer: &api.WritableLayerUsage{
+						Timestamp:  100,
+						UsedBytes:  7,
+						InodesUsed: 70,
+					},
+				},
+			},
+		},
+		"should filter by label selector": {
+			filter: &api.PodSandboxStatsFilter{LabelSelector: map[string]string{"c": "d"}},
+			expect: []*api.PodSandboxStats{
+				{
+					Id:     "s-2abcdef1234",
+					Labels: map[string]string{"c": "d"},
+					WritableLayer: &api.WritableLayerUsage{
+						Timestamp:  400,
+						UsedBytes:  8,
+						InodesUsed: 80,
+					},
+				},
+			},
+		},
+		"should skip sandbox failing to get stats": {
+			filter: &api.PodSandboxStatsFilter{Id: "s-3"},
+		},
+		"should return nothing if no sandbox matches": {
+			filter: &api.PodSandboxStatsFilter{LabelSelector: map[string]string{"e": "f"}},
+		},
+	} {
+		t.Logf("TestCase %q", desc)
+		resp, err := c.ListPodSandboxStats(context.Background(), &api.ListPodSandboxStatsRequest{Filter: test.filter})
+		require.NoError(t, err)
+		assert.Equal(t, test.expect, resp.GetStats())
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2018 The Containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	runtimespec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// seccompProfiles resolves, validates and caches seccomp profiles. Cached
+// profiles are reloaded when the profile files change.
+type seccompProfiles struct {
+	// root is the directory localhost/ profiles are resolved against.
+	root string
+	// defaultProfile is the profile used for runtime/default, nil if the
+	// built-in default profile is used.
+	defaultProfile *runtimespec.LinuxSeccomp
+	// applyDefault applies the default profile when no profile is specified.
+	applyDefault bool
+
+	lock  sync.Mutex
+	cache map[string]seccompProfileEntry
+}
+
+// seccompProfileEntry is a cached seccomp profile.
+type seccompProfileEntry struct {
+	modTime time.Time
+	size    int64
+	profile *runtimespec.LinuxSeccomp
+}
+
+// newSeccompProfiles loads the default profile, and validates and caches the
+// profiles in the profile root. A localhost/ profile is loaded again when a
+// container uses it, so an invalid profile in the profile root is only logged
+// here, and only fails the containers which use it.
+func newSeccompProfiles(root, defaultProfile string, applyDefault bool) (*seccompProfiles, error) {
+	s := &seccompProfiles{
+		root:         root,
+		applyDefault: applyDefault,
+		cache:        make(map[string]seccompProfileEntry),
+	}
+	if defaultProfile != "" {
+		p, err := s.get(defaultProfile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load default seccomp profile %q", defaultProfile)
+		}
+		s.defaultProfile = p
+	}
+	if root == "" {
+		return s, nil
+	}
+	if err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == root {
+				logrus.Warnf("Seccomp profile root %q doesn't exist", root)
+				return nil
+			}
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		if _, err := s.get(path); err != nil {
+			logrus.WithError(err).Errorf("Invalid seccomp profile %q", path)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to walk seccomp profile root %q", root)
+	}
+	return s, nil
+}
+
+// resolve returns the path of a localhost/ profile. The path must not
+// escape the profile root, even through symlinks.
+func (s *seccompProfiles) resolve(name string) (string, error) {
+	if s.root == "" {
+		return name, nil
+	}
+	path := filepath.Join(s.root, name)
+	if !isInDir(path, s.root) {
+		return "", errors.Errorf("seccomp profile %q is outside of profile root %q", name, s.root)
+	}
+	real, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to resolve seccomp profile %q", name)
+	}
+	root, err := filepath.EvalSymlinks(s.root)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to resolve seccomp profile root %q", s.root)
+	}
+	if !isInDir(real, root) {
+		return "", errors.Errorf("seccomp profile %q is outside of profile root %q", name, s.root)
+	}
+	return real, nil
+}
+
+// get returns the profile at the path. The cached profile is returned if
+// the file hasn't changed since it is loaded.
+func (s *seccompProfiles) get(path string) (*runtimespec.LinuxSeccomp, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to stat seccomp profile")
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if e, ok := s.cache[path]; ok && e.modTime.Equal(fi.ModTime()) && e.size == fi.Size() {
+		return e.profile, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read seccomp profile")
+	}
+	profile, err := parseSeccompProfile(data)
+	if err != nil {
+		return nil, err
+	}
+	s.cache[path] = seccompProfileEntry{
+		modTime: fi.ModTime(),
+		size:    fi.Size(),
+		profile: profile,
+	}
+	return profile, nil
+}
+
+// parseSeccompProfile parses and validates a seccomp profile in the format
+// of the runtime spec.
+func parseSeccompProfile(data []byte) (*runtimespec.LinuxSeccomp, error) {
+	var profile runtimespec.LinuxSeccomp
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return nil, errors.Wrap(err, "failed to decode seccomp profile")
+	}
+	if profile.DefaultAction == "" {
+		return nil, errors.New("default action is not set in seccomp profile")
+	}
+	for _, sc := range profile.Syscalls {
+		if len(sc.Names) == 0 {
+			return nil, errors.New("syscall names are not set in seccomp profile")
+		}
+		if sc.Action == "" {
+			return nil, errors.Errorf("action of syscalls %v is not set in seccomp profile", sc.Names)
+		}
+	}
+	return &profile, nil
+}
+
+// isInDir returns true if the path is dir or inside of dir.
+func isInDir(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2018 The Containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	runtimespec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeccompProfilesResolve(t *testing.T) {
+	dir, err := ioutil.TempDir("", "seccomp-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	root := filepath.Join(dir, "root")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "sub"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(root, "sub", "profile"), nil, 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "outside"), nil, 0644))
+	require.NoError(t, os.Symlink(filepath.Join(dir, "outside"), filepath.Join(root, "escape")))
+	require.NoError(t, os.Symlink("sub/profile", filepath.Join(root, "link")))
+
+	for desc, test := range map[string]struct {
+		root         string
+		name         string
+		expectedPath string
+		expectErr    bool
+	}{
+		"should resolve profile in root": {
+			root:         root,
+			name:         "sub/profile",
+			expectedPath: filepath.Join(root, "sub", "profile"),
+		},
+		"should resolve symlink in root": {
+			root:         root,
+			name:         "link",
+			expectedPath: filepath.Join(root, "sub", "profile"),
+		},
+		"should clean path in root": {
+			root:         root,
+			name:         "sub/../sub/profile",
+			expectedPath: filepath.Join(root, "sub", "profile"),
+		},
+		"should reject path escaping root": {
+			root:      root,
+			name:      "../outside",
+			expectErr: true,
+		},
+		"should reject symlink escaping root": {
+			root:      root,
+			name:      "escape",
+			expectErr: true,
+		},
+		"should use path as is without root": {
+			name:         "/test/profile",
+			expectedPath: "/test/profile",
+		},
+	} {
+		t.Logf("TestCase %q", desc)
+		s := &seccompProfiles{root: test.root}
+		path, err := s.resolve(test.name)
+		assert.Equal(t, test.expectErr, err != nil)
+		if !test.expectErr {
+			real, err := filepath.EvalSymlinks(test.expectedPath)
+			if err != nil {
+				real = test.expectedPath
+			}
+			assert.Equal(t, real, path)
+		}
+	}
+}
+
+func TestSeccompProfilesGet(t *testing.T) {
+	dir, err := ioutil.TempDir("", "seccomp-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "profile")
+	invalid := filepath.Join(dir, "invalid")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"defaultAction": "SCMP_ACT_ERRNO"}`), 0644))
+	require.NoError(t, ioutil.WriteFile(invalid, []byte(`{"syscalls": [{"names": ["read"]}]}`), 0644))
+
+	t.Logf("should load and cache valid profiles at startup")
+	s, err := newSeccompProfiles(dir, "", false)
+	require.NoError(t, err)
+	assert.Contains(t, s.cache, path)
+	assert.NotContains(t, s.cache, invalid)
+
+	t.Logf("should return cached profile")
+	profile, err := s.get(path)
+	require.NoError(t, err)
+	assert.Equal(t, runtimespec.LinuxSeccompAction("SCMP_ACT_ERRNO"), profile.DefaultAction)
+	cached, err := s.get(path)
+	require.NoError(t, err)
+	assert.True(t, profile == cached)
+
+	t.Logf("should reload profile after it is changed")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"defaultAction": "SCMP_ACT_ALLOW"}`), 0644))
+	future := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(path, future, future))
+	profile, err = s.get(path)
+	require.NoError(t, err)
+	assert.Equal(t, runtimespec.LinuxSeccompAction("SCMP_ACT_ALLOW"), profile.DefaultAction)
+
+	t.Logf("should return error for invalid profile")
+	_, err = s.get(invalid)
+	assert.Error(t, err)
+
+	t.Logf("should return error for invalid default profile")
+	_, err = newSeccompProfiles(dir, invalid, false)
+	assert.Error(t, err)
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/opencontainers/selinux/go-selinux"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
 	"k8s.io/kubernetes/pkg/kubelet/server/streaming"
@@ -39,11 +40,14 @@ import (
 	criconfig "github.com/containerd/cri/pkg/config"
 	ctrdutil "github.com/containerd/cri/pkg/containerd/util"
 	osinterface "github.com/containerd/cri/pkg/os"
+	"github.com/containerd/cri/pkg/quota"
 	"github.com/containerd/cri/pkg/registrar"
 	containerstore "github.com/containerd/cri/pkg/store/container"
 	imagestore "github.com/containerd/cri/pkg/store/image"
 	sandboxstore "github.com/containerd/cri/pkg/store/sandbox"
 	snapshotstore "github.com/containerd/cri/pkg/store/snapshot"
+	statsstore "github.com/containerd/cri/pkg/store/stats"
+	"github.com/containerd/cri/pkg/userns"
 )
 
 // grpcServices are all the grpc services provided by cri containerd.
@@ -72,6 +76,13 @@ type criService struct {
 	apparmorEnabled bool
 	// seccompEnabled indicates whether seccomp is enabled.
 	seccompEnabled bool
+	// seccompProfiles resolves and caches seccomp profiles.
+	seccompProfiles *seccompProfiles
+	// apparmorProfiles manages apparmor profiles. It is nil if apparmor is
+	// not enabled.
+	apparmorProfiles *apparmorProfiles
+	// ociHooks injects OCI hooks into container and sandbox specs.
+	ociHooks *ociHooks
 	// os is an interface for all required os operations.
 	os osinterface.OS
 	// sandboxStore stores all resources associated with sandboxes.
@@ -88,6 +99,14 @@ type criService struct {
 	imageStore *imagestore.Store
 	// snapshotStore stores information of all snapshots.
 	snapshotStore *snapshotstore.Store
+	// statsStore stores cpu/memory stats of all running containers.
+	statsStore *statsstore.Store
+	// quotaControl manages project quotas of writable layers. It is nil if
+	// project quota is not enabled or not supported.
+	quotaControl *quota.Control
+	// usernsAllocator allocates user namespace ID ranges to sandboxes. It is
+	// nil if user namespace remapping is not configured.
+	usernsAllocator *userns.Allocator
 	// netPlugin is used to setup and teardown network when run/stop pod sandbox.
 	netPlugin cni.CNI
 	// client is an instance of the containerd client
@@ -114,6 +133,7 @@ func NewCRIService(config criconfig.Config, client *containerd.Client) (CRIServi
 		containerStore:     containerstore.NewStore(),
 		imageStore:         imagestore.NewStore(),
 		snapshotStore:      snapshotstore.NewStore(),
+		statsStore:         statsstore.NewStore(),
 		sandboxNameIndex:   registrar.NewRegistrar(),
 		containerNameIndex: registrar.NewRegistrar(),
 		initialized:        atomic.NewBool(false),
@@ -130,6 +150,55 @@ func NewCRIService(config criconfig.Config, client *containerd.Client) (CRIServi
 	c.imageFSPath = imageFSPath(config.ContainerdRootDir, config.ContainerdConfig.Snapshotter)
 	logrus.Infof("Get image filesystem path %q", c.imageFSPath)
 
+	if c.config.EnableProjectQuota {
+		if c.config.ContainerdConfig.Snapshotter != overlayfsSnapshotter {
+			logrus.Warnf("Project quota is not supported by snapshotter %q", c.config.ContainerdConfig.Snapshotter)
+		} else if c.quotaControl, err = quota.NewControl(c.imageFSPath, c.config.StateDir); err != nil {
+			// Fall back to walking writable layers.
+			logrus.WithError(err).Warnf("Failed to enable project quota on %q", c.imageFSPath)
+			c.quotaControl = nil
+		}
+	}
+
+	c.seccompProfiles, err = newSeccompProfiles(c.config.SeccompProfileRoot,
+		c.config.DefaultSeccompProfile, c.config.ApplyDefaultSeccompProfile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load seccomp profiles")
+	}
+
+	if c.apparmorEnabled {
+		c.apparmorProfiles = newApparmorProfiles(c.config.ApparmorProfileDir,
+			c.config.ApparmorDefaultProfileTemplate,
+			time.Duration(c.config.ApparmorProfileSyncPeriod)*time.Second)
+		if err := c.apparmorProfiles.sync(); err != nil {
+			return nil, errors.Wrap(err, "failed to load apparmor profiles")
+		}
+	} else if c.config.ApparmorProfileDir != "" || c.config.ApparmorDefaultProfileTemplate != "" {
+		logrus.Warn("Apparmor is not supported, apparmor profiles are not loaded")
+	}
+
+	c.ociHooks, err = newOCIHooks(c.config.HooksDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load OCI hooks")
+	}
+
+	if r := c.config.UsernsRemap; r.Size > 0 {
+		c.usernsAllocator, err = userns.NewAllocator(r.Start, r.Size, r.PodSize)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create user namespace allocator")
+		}
+	}
+
+	if c.config.ImageGCPeriod > 0 {
+		high, low := c.config.ImageGCHighThresholdPercent, c.config.ImageGCLowThresholdPercent
+		if high < 0 || high > 100 || low < 0 || low > high {
+			return nil, errors.Errorf("invalid image gc thresholds: high %d%%, low %d%%", high, low)
+		}
+		if c.config.ImageGCMinAge < 0 {
+			return nil, errors.Errorf("invalid image gc minimum age %d", c.config.ImageGCMinAge)
+		}
+	}
+
 	// Pod needs to attach to atleast loopback network and a non host network,
 	// hence networkAttachCount is 2. If there are more network configs the
 	// pod will be attached to all the networks but we will only use the ip
@@ -177,6 +246,9 @@ func (c *criService) Run() error {
 		return errors.Wrap(err, "failed to recover state")
 	}
 
+	// Pull pinned images in the background.
+	c.pullPinnedImages()
+
 	// Start event handler.
 	logrus.Info("Start event monitor")
 	eventMonitorCloseCh, err := c.eventMonitor.start()
@@ -184,14 +256,81 @@ func (c *criService) Run() error {
 		return errors.Wrap(err, "failed to start event monitor")
 	}
 
-	// Start snapshot stats syncer, it doesn't need to be stopped.
-	logrus.Info("Start snapshots syncer")
-	snapshotsSyncer := newSnapshotsSyncer(
-		c.snapshotStore,
-		c.client.SnapshotService(c.config.ContainerdConfig.Snapshotter),
-		time.Duration(c.config.StatsCollectPeriod)*time.Second,
-	)
-	snapshotsSyncer.start()
+	// Start snapshot stats syncers for all snapshotters in use, they don't
+	// need to be stopped. Project quota is only supported for the global
+	// snapshotter.
+	for _, snapshotter := range c.getSnapshotters() {
+		logrus.Infof("Start snapshots syncer for snapshotter %q", snapshotter)
+		var q *quota.Control
+		if snapshotter == c.config.ContainerdConfig.Snapshotter {
+			q = c.quotaControl
+		}
+		snapshotsSyncer := newSnapshotsSyncer(
+			c.snapshotStore,
+			snapshotter,
+			c.client.SnapshotService(snapshotter),
+			time.Duration(c.config.StatsCollectPeriod)*time.Second,
+			q,
+		)
+		snapshotsSyncer.start()
+	}
+
+	// Start writable layer limit checker if the limit can't be enforced
+	// with project quota for all snapshotters, it doesn't need to be stopped.
+	if c.quotaControl == nil || len(c.getSnapshotters()) > 1 {
+		logrus.Info("Start writable layer limit checker")
+		limitChecker := newWritableLayerLimitChecker(
+			c.containerStore,
+			c.snapshotStore,
+			time.Duration(c.config.StatsCollectPeriod)*time.Second,
+			func(ctx context.Context, cntr containerstore.Container) error {
+				return c.stopContainer(ctx, cntr, 0)
+			},
+		)
+		limitChecker.start()
+	}
+
+	// Start apparmor profile sync, it doesn't need to be stopped.
+	if c.apparmorProfiles != nil {
+		c.apparmorProfiles.start()
+	}
+
+	// Start image gc manager, it doesn't need to be stopped.
+	if c.config.ImageGCPeriod > 0 {
+		logrus.Info("Start image gc manager")
+		imageGCManager := newImageGCManager(
+			c.imageStore,
+			c.containerStore,
+			c.snapshotStore,
+			c.config.ContainerdConfig.Snapshotter,
+			time.Duration(c.config.ImageGCPeriod)*time.Second,
+			time.Duration(c.config.ImageGCMinAge)*time.Second,
+			c.config.ImageGCHighThresholdPercent,
+			c.config.ImageGCLowThresholdPercent,
+			c.getPinnedImages(),
+			func() (uint64, error) {
+				return getFSCapacity(c.imageFSPath)
+			},
+			func(ctx context.Context, image imagestore.Image) error {
+				_, err := c.RemoveImage(ctx, &runtime.RemoveImageRequest{
+					Image: &runtime.ImageSpec{Image: image.ID},
+				})
+				return err
+			},
+		)
+		imageGCManager.start()
+	}
+
+	// Start container stats syncer, it doesn't need to be stopped.
+	if c.config.ContainerStatsCollectPeriod > 0 {
+		logrus.Info("Start container stats syncer")
+		statsSyncer := newStatsSyncer(
+			c.statsStore,
+			c.client.TaskService(),
+			time.Duration(c.config.ContainerStatsCollectPeriod)*time.Second,
+		)
+		statsSyncer.start()
+	}
 
 	// Start streaming server.
 	logrus.Info("Start streaming server")
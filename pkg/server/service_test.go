@@ -25,6 +25,7 @@ import (
 	imagestore "github.com/containerd/cri/pkg/store/image"
 	sandboxstore "github.com/containerd/cri/pkg/store/sandbox"
 	snapshotstore "github.com/containerd/cri/pkg/store/snapshot"
+	statsstore "github.com/containerd/cri/pkg/store/stats"
 )
 
 const (
@@ -52,9 +53,12 @@ func newTestCRIService() *criService {
 		sandboxStore:       sandboxstore.NewStore(),
 		imageStore:         imagestore.NewStore(),
 		snapshotStore:      snapshotstore.NewStore(),
+		statsStore:         statsstore.NewStore(),
 		sandboxNameIndex:   registrar.NewRegistrar(),
 		containerStore:     containerstore.NewStore(),
 		containerNameIndex: registrar.NewRegistrar(),
 		netPlugin:          servertesting.NewFakeCNIPlugin(),
+		seccompProfiles:    &seccompProfiles{cache: make(map[string]seccompProfileEntry)},
+		ociHooks:           &ociHooks{},
 	}
 }
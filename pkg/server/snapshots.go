@@ -18,34 +18,46 @@ package server
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/mount"
 	snapshot "github.com/containerd/containerd/snapshots"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
 	ctrdutil "github.com/containerd/cri/pkg/containerd/util"
+	"github.com/containerd/cri/pkg/quota"
 	snapshotstore "github.com/containerd/cri/pkg/store/snapshot"
 )
 
 // snapshotsSyncer syncs snapshot stats periodically. imagefs info and container stats
-// should both use cached result here.
-// TODO(random-liu): Benchmark with high workload. We may need a statsSyncer instead if
-// benchmark result shows that container cpu/memory stats also need to be cached.
+// should both use cached result here. Container cpu/memory stats are cached by
+// statsSyncer.
 type snapshotsSyncer struct {
-	store       *snapshotstore.Store
+	store *snapshotstore.Store
+	// name is the name of the snapshotter.
+	name        string
 	snapshotter snapshot.Snapshotter
 	syncPeriod  time.Duration
+	// quota is used to get usage of active overlayfs snapshots from project
+	// quota accounting. Usage is got from the snapshotter if it is nil.
+	quota *quota.Control
+	// upperDirs is the upper directories of snapshots assigned project ids.
+	upperDirs map[string]string
 }
 
-// newSnapshotsSyncer creates a snapshot syncer.
-func newSnapshotsSyncer(store *snapshotstore.Store, snapshotter snapshot.Snapshotter,
-	period time.Duration) *snapshotsSyncer {
+// newSnapshotsSyncer creates a snapshot syncer of a snapshotter.
+func newSnapshotsSyncer(store *snapshotstore.Store, name string, snapshotter snapshot.Snapshotter,
+	period time.Duration, q *quota.Control) *snapshotsSyncer {
 	return &snapshotsSyncer{
 		store:       store,
+		name:        name,
 		snapshotter: snapshotter,
 		syncPeriod:  period,
+		quota:       q,
+		upperDirs:   make(map[string]string),
 	}
 }
 
@@ -56,11 +68,11 @@ func (s *snapshotsSyncer) start() {
 	tick := time.NewTicker(s.syncPeriod)
 	go func() {
 		defer tick.Stop()
-		// TODO(random-liu): This is expensive. We should do benchmark to
-		// check the resource usage and optimize this.
+		// TODO(random-liu): This is expensive without project quota. We
+		// should do benchmark to check the resource usage and optimize this.
 		for {
 			if err := s.sync(); err != nil {
-				logrus.WithError(err).Error("Failed to sync snapshot stats")
+				logrus.WithError(err).Errorf("Failed to sync snapshot stats of snapshotter %q", s.name)
 			}
 			<-tick.C
 		}
@@ -83,7 +95,7 @@ func (s *snapshotsSyncer) sync() error {
 		return errors.Wrap(err, "walk all snapshots failed")
 	}
 	for _, info := range snapshots {
-		sn, err := s.store.Get(info.Name)
+		sn, err := s.store.Get(s.name, info.Name)
 		if err == nil {
 			// Only update timestamp for non-active snapshot.
 			if sn.Kind == info.Kind && sn.Kind != snapshot.KindActive {
@@ -94,11 +106,12 @@ func (s *snapshotsSyncer) sync() error {
 		}
 		// Get newest stats if the snapshot is new or active.
 		sn = snapshotstore.Snapshot{
-			Key:       info.Name,
-			Kind:      info.Kind,
-			Timestamp: time.Now().UnixNano(),
+			Snapshotter: s.name,
+			Key:         info.Name,
+			Kind:        info.Kind,
+			Timestamp:   time.Now().UnixNano(),
 		}
-		usage, err := s.snapshotter.Usage(ctx, info.Name)
+		usage, err := s.usage(ctx, info)
 		if err != nil {
 			if !errdefs.IsNotFound(err) {
 				logrus.WithError(err).Errorf("Failed to get usage for snapshot %q", info.Name)
@@ -110,11 +123,67 @@ func (s *snapshotsSyncer) sync() error {
 		s.store.Add(sn)
 	}
 	for _, sn := range s.store.List() {
-		if sn.Timestamp >= start {
+		if sn.Snapshotter != s.name || sn.Timestamp >= start {
 			continue
 		}
 		// Delete the snapshot stats if it's not updated this time.
-		s.store.Delete(sn.Key)
+		s.store.Delete(s.name, sn.Key)
+		if upperDir, ok := s.upperDirs[sn.Key]; ok {
+			s.quota.Release(upperDir)
+			delete(s.upperDirs, sn.Key)
+		}
 	}
 	return nil
 }
+
+// usage returns the usage of the snapshot. Usage of active snapshot is got
+// from project quota accounting if possible, because walking the snapshot
+// is expensive.
+func (s *snapshotsSyncer) usage(ctx context.Context, info snapshot.Info) (snapshot.Usage, error) {
+	if s.quota == nil || info.Kind != snapshot.KindActive {
+		return s.snapshotter.Usage(ctx, info.Name)
+	}
+	upperDir, err := s.quotaUpperDir(ctx, info.Name)
+	if err == nil {
+		var usage quota.Usage
+		if usage, err = s.quota.GetUsage(upperDir); err == nil {
+			return snapshot.Usage{Size: usage.Size, Inodes: usage.Inodes}, nil
+		}
+	}
+	logrus.WithError(err).Debugf("Failed to get usage for snapshot %q from project quota", info.Name)
+	return s.snapshotter.Usage(ctx, info.Name)
+}
+
+// quotaUpperDir returns the upper directory of an active overlayfs snapshot,
+// and makes sure it is assigned a project id.
+func (s *snapshotsSyncer) quotaUpperDir(ctx context.Context, key string) (string, error) {
+	mounts, err := s.snapshotter.Mounts(ctx, key)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get mounts")
+	}
+	upperDir, err := getUpperDir(mounts)
+	if err != nil {
+		return "", err
+	}
+	if _, err := s.quota.AssignProjectID(upperDir); err != nil {
+		return "", errors.Wrapf(err, "failed to assign project id to %q", upperDir)
+	}
+	s.upperDirs[key] = upperDir
+	return upperDir, nil
+}
+
+// getUpperDir returns the overlay upper directory in the mounts.
+func getUpperDir(mounts []mount.Mount) (string, error) {
+	const upperDirPrefix = "upperdir="
+	for _, m := range mounts {
+		if m.Type != "overlay" {
+			continue
+		}
+		for _, o := range m.Options {
+			if strings.HasPrefix(o, upperDirPrefix) {
+				return strings.TrimPrefix(o, upperDirPrefix), nil
+			}
+		}
+	}
+	return "", errors.Errorf("overlay upper directory not found in mounts %+v", mounts)
+}
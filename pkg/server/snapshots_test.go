@@ -0,0 +1,91 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"testing"
+
+	"github.com/containerd/containerd/mount"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUpperDir(t *testing.T) {
+	for desc, test := range map[string]struct {
+		mounts    []mount.Mount
+		expected  string
+		expectErr bool
+	}{
+		"should return upper directory of overlay mount": {
+			mounts: []mount.Mount{
+				{
+					Type:   "overlay",
+					Source: "overlay",
+					Options: []string{
+						"workdir=/snapshots/2/work",
+						"upperdir=/snapshots/2/fs",
+						"lowerdir=/snapshots/1/fs",
+					},
+				},
+			},
+			expected: "/snapshots/2/fs",
+		},
+		"should return upper directory of the overlay mount in multiple mounts": {
+			mounts: []mount.Mount{
+				{
+					Type:    "bind",
+					Source:  "/snapshots/1/fs",
+					Options: []string{"rw", "rbind"},
+				},
+				{
+					Type:    "overlay",
+					Source:  "overlay",
+					Options: []string{"upperdir=/snapshots/3/fs", "lowerdir=/snapshots/1/fs"},
+				},
+			},
+			expected: "/snapshots/3/fs",
+		},
+		"should return error for bind mount": {
+			mounts: []mount.Mount{
+				{
+					Type:    "bind",
+					Source:  "/snapshots/1/fs",
+					Options: []string{"rw", "rbind"},
+				},
+			},
+			expectErr: true,
+		},
+		"should return error for overlay mount without upper directory": {
+			mounts: []mount.Mount{
+				{
+					Type:    "overlay",
+					Source:  "overlay",
+					Options: []string{"lowerdir=/snapshots/2/fs:/snapshots/1/fs"},
+				},
+			},
+			expectErr: true,
+		},
+	} {
+		t.Logf("TestCase %q", desc)
+		got, err := getUpperDir(test.mounts)
+		if test.expectErr {
+			assert.Error(t, err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, got)
+	}
+}
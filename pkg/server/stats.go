@@ -0,0 +1,138 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"time"
+
+	"github.com/containerd/cgroups"
+	tasks "github.com/containerd/containerd/api/services/tasks/v1"
+	"github.com/containerd/containerd/api/types"
+	"github.com/containerd/typeurl"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	ctrdutil "github.com/containerd/cri/pkg/containerd/util"
+	statsstore "github.com/containerd/cri/pkg/store/stats"
+)
+
+// statsSyncer syncs container cpu/memory stats periodically. Container stats
+// should use cached result here, so that frequent stats requests don't
+// need to query containerd every time.
+type statsSyncer struct {
+	store      *statsstore.Store
+	tasks      tasks.TasksClient
+	syncPeriod time.Duration
+}
+
+// newStatsSyncer creates a stats syncer.
+func newStatsSyncer(store *statsstore.Store, tasks tasks.TasksClient,
+	period time.Duration) *statsSyncer {
+	return &statsSyncer{
+		store:      store,
+		tasks:      tasks,
+		syncPeriod: period,
+	}
+}
+
+// start starts the stats syncer. No stop function is needed because
+// the syncer doesn't update any persistent states, it's fine to let it
+// exit with the process.
+func (s *statsSyncer) start() {
+	tick := time.NewTicker(s.syncPeriod)
+	go func() {
+		defer tick.Stop()
+		for {
+			if err := s.sync(); err != nil {
+				logrus.WithError(err).Error("Failed to sync container stats")
+			}
+			<-tick.C
+		}
+	}()
+}
+
+// sync updates stats of all running containers.
+func (s *statsSyncer) sync() error {
+	ctx := ctrdutil.NamespacedContext()
+	// TODO(random-liu): Set timeout for the context.
+	resp, err := s.tasks.Metrics(ctx, &tasks.MetricsRequest{})
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch metrics for tasks")
+	}
+	updated := make(map[string]bool)
+	for _, m := range resp.Metrics {
+		stats, err := toContainerStats(m)
+		if err != nil {
+			logrus.WithError(err).Errorf("Failed to decode metrics for %q", m.ID)
+			continue
+		}
+		if old, err := s.store.Get(m.ID); err == nil {
+			stats.UsageNanoCores = getUsageNanoCores(old, stats)
+		}
+		s.store.Add(stats)
+		updated[m.ID] = true
+	}
+	for _, stats := range s.store.List() {
+		if updated[stats.ID] {
+			continue
+		}
+		// Delete the stats if the task doesn't exist anymore.
+		s.store.Delete(stats.ID)
+	}
+	return nil
+}
+
+// toContainerStats decodes task metric into container stats.
+func toContainerStats(m *types.Metric) (statsstore.ContainerStats, error) {
+	s, err := typeurl.UnmarshalAny(m.Data)
+	if err != nil {
+		return statsstore.ContainerStats{}, errors.Wrap(err, "failed to extract container metrics")
+	}
+	metrics, ok := s.(*cgroups.Metrics)
+	if !ok {
+		return statsstore.ContainerStats{}, errors.Errorf("unexpected metrics type: %T", s)
+	}
+	return statsstore.ContainerStats{
+		ID:        m.ID,
+		Timestamp: m.Timestamp.UnixNano(),
+		Metrics:   metrics,
+	}, nil
+}
+
+// getUsageNanoCores computes the cpu usage in nanocores between two samples.
+// It returns 0 if the usage can't be computed, e.g. the container is restarted
+// between the two samples.
+func getUsageNanoCores(old, new statsstore.ContainerStats) uint64 {
+	oldUsage, newUsage := getCPUUsage(old.Metrics), getCPUUsage(new.Metrics)
+	if oldUsage == nil || newUsage == nil {
+		return 0
+	}
+	interval := new.Timestamp - old.Timestamp
+	if interval <= 0 || newUsage.Total < oldUsage.Total {
+		return 0
+	}
+	// Use float64 to avoid overflow.
+	return uint64(float64(newUsage.Total-oldUsage.Total) / float64(interval) * float64(time.Second))
+}
+
+// getCPUUsage returns cpu usage in the metrics, or nil if it is not set.
+func getCPUUsage(metrics *cgroups.Metrics) *cgroups.CPUUsage {
+	if metrics == nil || metrics.CPU == nil {
+		return nil
+	}
+	return metrics.CPU.Usage
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/containerd/cgroups"
+	"github.com/stretchr/testify/assert"
+
+	statsstore "github.com/containerd/cri/pkg/store/stats"
+)
+
+func TestGetUsageNanoCores(t *testing.T) {
+	newStats := func(timestamp int64, total uint64) statsstore.ContainerStats {
+		return statsstore.ContainerStats{
+			ID:        "test-id",
+			Timestamp: timestamp,
+			Metrics: &cgroups.Metrics{
+				CPU: &cgroups.CPUStat{Usage: &cgroups.CPUUsage{Total: total}},
+			},
+		}
+	}
+	const second = int64(time.Second)
+	for desc, test := range map[string]struct {
+		old    statsstore.ContainerStats
+		new    statsstore.ContainerStats
+		expect uint64
+	}{
+		"should compute usage between two samples": {
+			old:    newStats(second, 1000),
+			new:    newStats(3*second, uint64(2*second)+1000),
+			expect: uint64(second),
+		},
+		"should compute usage across multiple cores": {
+			old:    newStats(second, 0),
+			new:    newStats(2*second, uint64(4*second)),
+			expect: 4 * uint64(second),
+		},
+		"should return 0 if timestamp doesn't increase": {
+			old:    newStats(second, 0),
+			new:    newStats(second, 1000),
+			expect: 0,
+		},
+		"should return 0 if usage decreases": {
+			old:    newStats(second, 1000),
+			new:    newStats(2*second, 10),
+			expect: 0,
+		},
+		"should return 0 if cpu usage is not set": {
+			old:    statsstore.ContainerStats{Timestamp: second, Metrics: &cgroups.Metrics{}},
+			new:    newStats(2*second, 1000),
+			expect: 0,
+		},
+	} {
+		t.Logf("TestCase %q", desc)
+		assert.Equal(t, test.expect, getUsageNanoCores(test.old, test.new))
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"k8s.io/apimachinery/pkg/api/resource"
+	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
+
+	"github.com/containerd/cri/pkg/annotations"
+	criconfig "github.com/containerd/cri/pkg/config"
+	ctrdutil "github.com/containerd/cri/pkg/containerd/util"
+	containerstore "github.com/containerd/cri/pkg/store/container"
+	snapshotstore "github.com/containerd/cri/pkg/store/snapshot"
+)
+
+// getWritableLayerLimit returns the writable layer limit of a container. The
+// container annotation takes precedence over the default of the runtime.
+func getWritableLayerLimit(config *runtime.ContainerConfig, ociRuntime criconfig.Runtime) (int64, error) {
+	if value, ok := config.GetAnnotations()[annotations.WritableLayerLimit]; ok {
+		q, err := resource.ParseQuantity(value)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to parse annotation %q", annotations.WritableLayerLimit)
+		}
+		limit := q.Value()
+		if limit < 0 {
+			return 0, errors.Errorf("negative writable layer limit %q", value)
+		}
+		return limit, nil
+	}
+	return ociRuntime.WritableLayerLimit, nil
+}
+
+// setWritableLayerQuota sets project quota limit on the upper directory of
+// the container writable layer.
+func (c *criService) setWritableLayerQuota(ctx context.Context, id string, limit int64) error {
+	mounts, err := c.client.SnapshotService(c.config.ContainerdConfig.Snapshotter).Mounts(ctx, id)
+	if err != nil {
+		return errors.Wrap(err, "failed to get snapshot mounts")
+	}
+	upperDir, err := getUpperDir(mounts)
+	if err != nil {
+		return err
+	}
+	if _, err := c.quotaControl.AssignProjectID(upperDir); err != nil {
+		return errors.Wrapf(err, "failed to assign project id to %q", upperDir)
+	}
+	if err := c.quotaControl.SetLimit(upperDir, uint64(limit)); err != nil {
+		return errors.Wrapf(err, "failed to set quota limit for %q", upperDir)
+	}
+	return nil
+}
+
+// writableLayerLimitChecker kills containers whose writable layer usage
+// exceeds the limit. It is used when the limit can't be enforced with
+// project quota. Usage is got from the snapshot store, so the check is
+// only as fresh as snapshot stats.
+type writableLayerLimitChecker struct {
+	containerStore *containerstore.Store
+	snapshotStore  *snapshotstore.Store
+	checkPeriod    time.Duration
+	// kill kills the container.
+	kill func(context.Context, containerstore.Container) error
+}
+
+// newWritableLayerLimitChecker creates a writable layer limit checker.
+func newWritableLayerLimitChecker(containerStore *containerstore.Store, snapshotStore *snapshotstore.Store,
+	period time.Duration, kill func(context.Context, containerstore.Container) error) *writableLayerLimitChecker {
+	return &writableLayerLimitChecker{
+		containerStore: containerStore,
+		snapshotStore:  snapshotStore,
+		checkPeriod:    period,
+		kill:           kill,
+	}
+}
+
+// start starts the writable layer limit checker. No stop function is needed
+// because the checker doesn't own any resource, it's fine to let it exit with
+// the process.
+func (w *writableLayerLimitChecker) start() {
+	tick := time.NewTicker(w.checkPeriod)
+	go func() {
+		defer tick.Stop()
+		for {
+			w.check()
+			<-tick.C
+		}
+	}()
+}
+
+// check kills all running containers exceeding their writable layer limits.
+func (w *writableLayerLimitChecker) check() {
+	ctx := ctrdutil.NamespacedContext()
+	for _, cntr := range w.containerStore.List() {
+		limit := cntr.WritableLayerLimit
+		if limit <= 0 {
+			continue
+		}
+		// Paused containers can't be killed, and their writable layers
+		// don't grow.
+		if status := cntr.Status.Get(); status.State() != runtime.ContainerState_CONTAINER_RUNNING || status.Paused {
+			continue
+		}
+		sn, err := w.snapshotStore.Get(cntr.Snapshotter, cntr.ID)
+		if err != nil {
+			// The snapshot stats may not be collected yet.
+			continue
+		}
+		if sn.Size <= uint64(limit) {
+			continue
+		}
+		logrus.Infof("Kill container %q with writable layer usage %d bytes exceeding limit %d bytes",
+			cntr.ID, sn.Size, limit)
+		if err := cntr.Status.UpdateSync(func(status containerstore.Status) (containerstore.Status, error) {
+			status.Reason = writableLayerLimitExceededReason
+			status.Message = fmt.Sprintf("writable layer usage %d bytes exceeds limit %d bytes", sn.Size, limit)
+			return status, nil
+		}); err != nil {
+			logrus.WithError(err).Errorf("Failed to update status of container %q", cntr.ID)
+			continue
+		}
+		if err := w.kill(ctx, cntr); err != nil {
+			logrus.WithError(err).Errorf("Failed to kill container %q", cntr.ID)
+		}
+	}
+}
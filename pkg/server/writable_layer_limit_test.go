@@ -0,0 +1,142 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	snapshot "github.com/containerd/containerd/snapshots"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	runtime "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
+
+	"github.com/containerd/cri/pkg/annotations"
+	criconfig "github.com/containerd/cri/pkg/config"
+	containerstore "github.com/containerd/cri/pkg/store/container"
+	snapshotstore "github.com/containerd/cri/pkg/store/snapshot"
+)
+
+func TestGetWritableLayerLimit(t *testing.T) {
+	ociRuntime := criconfig.Runtime{
+		Type:               "default",
+		WritableLayerLimit: 1024,
+	}
+	for desc, test := range map[string]struct {
+		annotations map[string]string
+		expectErr   bool
+		expected    int64
+	}{
+		"should use default of runtime": {
+			expected: 1024,
+		},
+		"should use annotation over runtime default": {
+			annotations: map[string]string{annotations.WritableLayerLimit: "10Mi"},
+			expected:    10 * 1024 * 1024,
+		},
+		"should allow annotation to remove limit": {
+			annotations: map[string]string{annotations.WritableLayerLimit: "0"},
+			expected:    0,
+		},
+		"should return error for invalid annotation": {
+			annotations: map[string]string{annotations.WritableLayerLimit: "invalid"},
+			expectErr:   true,
+		},
+		"should return error for negative annotation": {
+			annotations: map[string]string{annotations.WritableLayerLimit: "-1Gi"},
+			expectErr:   true,
+		},
+	} {
+		t.Logf("TestCase %q", desc)
+		config := &runtime.ContainerConfig{Annotations: test.annotations}
+		limit, err := getWritableLayerLimit(config, ociRuntime)
+		if test.expectErr {
+			assert.Error(t, err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, limit)
+	}
+}
+
+func TestWritableLayerLimitCheck(t *testing.T) {
+	running := containerstore.Status{
+		CreatedAt: time.Now().UnixNano(),
+		StartedAt: time.Now().UnixNano(),
+	}
+	for desc, test := range map[string]struct {
+		limit      int64
+		status     containerstore.Status
+		usage      *uint64
+		expectKill bool
+	}{
+		"should kill running container exceeding limit": {
+			limit:      100,
+			status:     running,
+			usage:      uint64Ptr(101),
+			expectKill: true,
+		},
+		"should not kill container within limit": {
+			limit:  100,
+			status: running,
+			usage:  uint64Ptr(100),
+		},
+		"should not kill container without limit": {
+			status: running,
+			usage:  uint64Ptr(101),
+		},
+		"should not kill container not running": {
+			limit:  100,
+			status: containerstore.Status{CreatedAt: time.Now().UnixNano()},
+			usage:  uint64Ptr(101),
+		},
+		"should not kill container without usage": {
+			limit:  100,
+			status: running,
+		},
+	} {
+		t.Logf("TestCase %q", desc)
+		containerStore := containerstore.NewStore()
+		snapshotStore := snapshotstore.NewStore()
+		cntr, err := containerForTest{
+			metadata: containerstore.Metadata{ID: "test-id", WritableLayerLimit: test.limit},
+			status:   test.status,
+		}.toContainer()
+		assert.NoError(t, err)
+		assert.NoError(t, containerStore.Add(cntr))
+		if test.usage != nil {
+			snapshotStore.Add(snapshotstore.Snapshot{Key: "test-id", Kind: snapshot.KindActive, Size: *test.usage})
+		}
+		var killed []string
+		checker := newWritableLayerLimitChecker(containerStore, snapshotStore, time.Second,
+			func(_ context.Context, cntr containerstore.Container) error {
+				killed = append(killed, cntr.ID)
+				return nil
+			})
+		checker.check()
+		if !test.expectKill {
+			assert.Empty(t, killed)
+			assert.Empty(t, cntr.Status.Get().Reason)
+			continue
+		}
+		assert.Equal(t, []string{"test-id"}, killed)
+		assert.Equal(t, writableLayerLimitExceededReason, cntr.Status.Get().Reason)
+		assert.NotEmpty(t, cntr.Status.Get().Message)
+	}
+}
+
+func uint64Ptr(v uint64) *uint64 { return &v }
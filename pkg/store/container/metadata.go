@@ -58,6 +58,13 @@ type Metadata struct {
 	ImageRef string
 	// LogPath is the container log path.
 	LogPath string
+	// WritableLayerLimit is the maximum size in bytes of the container
+	// writable layer. 0 means no limit.
+	WritableLayerLimit int64
+	// Snapshotter is the snapshotter of the container writable layer.
+	Snapshotter string
+	// Hooks are the names of the OCI hooks injected into the container spec.
+	Hooks []string
 }
 
 // MarshalJSON encodes Metadata into bytes in json format.
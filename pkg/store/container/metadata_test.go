@@ -37,6 +37,7 @@ func TestMetadataMarshalUnmarshal(t *testing.T) {
 		},
 		ImageRef: "test-image-ref",
 		LogPath:  "/test/log/path",
+		Hooks:    []string{"test-hook.json"},
 	}
 
 	assert := assertlib.New(t)
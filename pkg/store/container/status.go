@@ -56,6 +56,11 @@ type Status struct {
 	// Human-readable message indicating details about why container is in its
 	// current state.
 	Message string
+	// Paused indicates that the container is paused. A paused container is
+	// still in running state.
+	Paused bool
+	// PausedAt is the timestamp the container is paused at.
+	PausedAt int64
 	// Removing indicates that the container is in removing state.
 	// This field doesn't need to be checkpointed.
 	Removing bool `json:"-"`
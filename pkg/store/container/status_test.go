@@ -52,6 +52,15 @@ func TestContainerState(t *testing.T) {
 			},
 			state: runtime.ContainerState_CONTAINER_RUNNING,
 		},
+		"paused state": {
+			status: Status{
+				CreatedAt: time.Now().UnixNano(),
+				StartedAt: time.Now().UnixNano(),
+				Paused:    true,
+				PausedAt:  time.Now().UnixNano(),
+			},
+			state: runtime.ContainerState_CONTAINER_RUNNING,
+		},
 		"exited state": {
 			status: Status{
 				CreatedAt:  time.Now().UnixNano(),
@@ -74,6 +83,8 @@ func TestStatusEncodeDecode(t *testing.T) {
 		ExitCode:   1,
 		Reason:     "test-reason",
 		Message:    "test-message",
+		Paused:     true,
+		PausedAt:   time.Now().UnixNano(),
 		Removing:   true,
 	}
 	assert := assertlib.New(t)
@@ -44,6 +44,18 @@ type Image struct {
 	ImageSpec imagespec.Image
 	// Containerd image reference
 	Image containerd.Image
+	// Platform is the platform selected from the manifest list of the image.
+	Platform imagespec.Platform
+	// PulledAt is the last time (in nanoseconds) when the image is pulled.
+	// It is 0 if unknown.
+	PulledAt int64
+	// LastUsedAt is the last time (in nanoseconds) when the image is used
+	// to create a container or sandbox. It is 0 if unknown.
+	LastUsedAt int64
+	// Broken indicates that the image can't be used, e.g. its content is
+	// missing. Broken image is hidden from the user, so that it is pulled
+	// again.
+	Broken bool
 }
 
 // Store stores all images.
@@ -80,13 +92,66 @@ func (s *Store) Add(img Image) error {
 		s.images[img.ID] = img
 		return nil
 	}
-	// Or else, merge the repo tags/digests.
+	// Or else, merge the repo tags/digests and keep the latest timestamps.
+	// The broken state of the newly added image wins, e.g. a broken image is
+	// fixed by pulling it again.
 	i.RepoTags = mergeStringSlices(i.RepoTags, img.RepoTags)
+	i.Broken = img.Broken
 	i.RepoDigests = mergeStringSlices(i.RepoDigests, img.RepoDigests)
+	if img.PulledAt > i.PulledAt {
+		i.PulledAt = img.PulledAt
+	}
+	if img.LastUsedAt > i.LastUsedAt {
+		i.LastUsedAt = img.LastUsedAt
+	}
 	s.images[img.ID] = i
 	return nil
 }
 
+// UpdateLastUsed updates the last used time of the image with specified id.
+// Returns store.ErrNotExist if the image doesn't exist.
+func (s *Store) UpdateLastUsed(id string, lastUsedAt int64) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	digest, err := s.digestSet.Lookup(id)
+	if err != nil {
+		if err == digestset.ErrDigestNotFound {
+			err = store.ErrNotExist
+		}
+		return err
+	}
+	i, ok := s.images[digest.String()]
+	if !ok {
+		return store.ErrNotExist
+	}
+	if lastUsedAt > i.LastUsedAt {
+		i.LastUsedAt = lastUsedAt
+		s.images[digest.String()] = i
+	}
+	return nil
+}
+
+// SetBroken marks the image with specified id as broken or not.
+// Returns store.ErrNotExist if the image doesn't exist.
+func (s *Store) SetBroken(id string, broken bool) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	digest, err := s.digestSet.Lookup(id)
+	if err != nil {
+		if err == digestset.ErrDigestNotFound {
+			err = store.ErrNotExist
+		}
+		return err
+	}
+	i, ok := s.images[digest.String()]
+	if !ok {
+		return store.ErrNotExist
+	}
+	i.Broken = broken
+	s.images[digest.String()] = i
+	return nil
+}
+
 // Get returns the image with specified id. Returns store.ErrNotExist if the
 // image doesn't exist.
 func (s *Store) Get(id string) (Image, error) {
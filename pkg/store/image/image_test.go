@@ -130,6 +130,34 @@ func TestImageStore(t *testing.T) {
 		assert.Len(got.RepoDigests, 2)
 		assert.Contains(got.RepoDigests, oldRepoDigest, newRepoDigest)
 
+		t.Logf("should keep the latest pulled time")
+		newImg.PulledAt = 200
+		assert.NoError(s.Add(newImg))
+		newImg.PulledAt = 100
+		assert.NoError(s.Add(newImg))
+		got, err = s.Get(truncID)
+		assert.NoError(err)
+		assert.EqualValues(200, got.PulledAt)
+
+		t.Logf("should be able to update last used time")
+		assert.NoError(s.UpdateLastUsed(truncID, 300))
+		assert.NoError(s.UpdateLastUsed(truncID, 100))
+		got, err = s.Get(truncID)
+		assert.NoError(err)
+		assert.EqualValues(300, got.LastUsedAt)
+
+		t.Logf("should be able to mark image as broken")
+		assert.NoError(s.SetBroken(truncID, true))
+		got, err = s.Get(truncID)
+		assert.NoError(err)
+		assert.True(got.Broken)
+
+		t.Logf("adding the image again should fix the broken image")
+		assert.NoError(s.Add(Image{ID: got.ID}))
+		got, err = s.Get(truncID)
+		assert.NoError(err)
+		assert.False(got.Broken)
+
 		t.Logf("should be able to delete image")
 		s.Delete(truncID)
 		imageNum--
@@ -140,5 +168,11 @@ func TestImageStore(t *testing.T) {
 		img, err := s.Get(truncID)
 		assert.Equal(Image{}, img)
 		assert.Equal(store.ErrNotExist, err)
+
+		t.Logf("update last used time should return ErrNotExist after deletion")
+		assert.Equal(store.ErrNotExist, s.UpdateLastUsed(truncID, 400))
+
+		t.Logf("set broken should return ErrNotExist after deletion")
+		assert.Equal(store.ErrNotExist, s.SetBroken(truncID, true))
 	}
 }
@@ -54,6 +54,24 @@ type Metadata struct {
 	NetNSPath string
 	// IP of Pod if it is attached to non host network
 	IP string
+	// RuntimeHandler is the runtime handler name of the sandbox. Containers
+	// in the sandbox run with the same runtime handler.
+	RuntimeHandler string
+	// Snapshotter is the snapshotter of the sandbox container.
+	Snapshotter string
+	// UserNamespace is the user namespace ID mapping of the sandbox, nil if
+	// the sandbox doesn't run in a user namespace.
+	UserNamespace *UserNamespace
+}
+
+// UserNamespace is the ID mapping of a sandbox user namespace. UID/GID
+// [0, Size) in the user namespace are mapped to [HostID, HostID+Size) on
+// the host.
+type UserNamespace struct {
+	// HostID is the first host UID/GID of the mapped range.
+	HostID uint32
+	// Size is the size of the mapped range.
+	Size uint32
 }
 
 // MarshalJSON encodes Metadata into bytes in json format.
@@ -36,6 +36,10 @@ func TestMetadataMarshalUnmarshal(t *testing.T) {
 				Attempt:   1,
 			},
 		},
+		UserNamespace: &UserNamespace{
+			HostID: 100000,
+			Size:   65536,
+		},
 	}
 	assert := assertlib.New(t)
 	newMeta := &Metadata{}
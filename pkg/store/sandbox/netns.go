@@ -17,7 +17,10 @@ limitations under the License.
 package sandbox
 
 import (
+	"crypto/rand"
+	"fmt"
 	"os"
+	"path/filepath"
 	"sync"
 
 	cnins "github.com/containernetworking/plugins/pkg/ns"
@@ -49,6 +52,44 @@ func NewNetNS() (*NetNS, error) {
 	return n, nil
 }
 
+// netNSRunDir is the directory where network namespaces are bind mounted.
+const netNSRunDir = "/var/run/netns"
+
+// NewNetNSFromPID bind mounts the network namespace of the process, so that
+// it outlives the process. It is used when the network namespace is created
+// by the runtime, e.g. when it must be owned by the user namespace of the
+// sandbox.
+func NewNetNSFromPID(pid uint32) (_ *NetNS, retErr error) {
+	b := make([]byte, 16)
+	if _, err := rand.Reader.Read(b); err != nil {
+		return nil, errors.Wrap(err, "failed to generate random netns name")
+	}
+	if err := os.MkdirAll(netNSRunDir, 0755); err != nil {
+		return nil, errors.Wrapf(err, "failed to create %q", netNSRunDir)
+	}
+	path := filepath.Join(netNSRunDir, fmt.Sprintf("cni-%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]))
+	f, err := os.OpenFile(path, os.O_RDONLY|os.O_CREATE|os.O_EXCL, 0444)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create netns file %q", path)
+	}
+	f.Close()
+	defer func() {
+		if retErr != nil {
+			os.RemoveAll(path) // nolint: errcheck
+		}
+	}()
+	src := fmt.Sprintf("/proc/%d/ns/net", pid)
+	if err := unix.Mount(src, path, "none", unix.MS_BIND, ""); err != nil {
+		return nil, errors.Wrapf(err, "failed to bind mount %q to %q", src, path)
+	}
+	ns, err := cnins.GetNS(path)
+	if err != nil {
+		unix.Unmount(path, unix.MNT_DETACH) // nolint: errcheck
+		return nil, errors.Wrap(err, "failed to load network namespace")
+	}
+	return &NetNS{ns: ns, restored: true}, nil
+}
+
 // LoadNetNS loads existing network namespace. It returns ErrClosedNetNS
 // if the network namespace has already been closed.
 func LoadNetNS(path string) (*NetNS, error) {
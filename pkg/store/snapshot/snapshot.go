@@ -26,6 +26,8 @@ import (
 
 // Snapshot contains the information about the snapshot.
 type Snapshot struct {
+	// Snapshotter is the snapshotter of the snapshot.
+	Snapshotter string
 	// Key is the key of the snapshot
 	Key string
 	// Kind is the kind of the snapshot (active, commited, view)
@@ -39,30 +41,37 @@ type Snapshot struct {
 	Timestamp int64
 }
 
+// snapshotKey identifies a snapshot in the store, snapshots in different
+// snapshotters may have the same key.
+type snapshotKey struct {
+	snapshotter string
+	key         string
+}
+
 // Store stores all snapshots.
 type Store struct {
 	lock      sync.RWMutex
-	snapshots map[string]Snapshot
+	snapshots map[snapshotKey]Snapshot
 }
 
 // NewStore creates a snapshot store.
 func NewStore() *Store {
-	return &Store{snapshots: make(map[string]Snapshot)}
+	return &Store{snapshots: make(map[snapshotKey]Snapshot)}
 }
 
 // Add a snapshot into the store.
 func (s *Store) Add(snapshot Snapshot) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
-	s.snapshots[snapshot.Key] = snapshot
+	s.snapshots[snapshotKey{snapshotter: snapshot.Snapshotter, key: snapshot.Key}] = snapshot
 }
 
-// Get returns the snapshot with specified key. Returns store.ErrNotExist if the
-// snapshot doesn't exist.
-func (s *Store) Get(key string) (Snapshot, error) {
+// Get returns the snapshot with specified snapshotter and key. Returns
+// store.ErrNotExist if the snapshot doesn't exist.
+func (s *Store) Get(snapshotter, key string) (Snapshot, error) {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
-	if sn, ok := s.snapshots[key]; ok {
+	if sn, ok := s.snapshots[snapshotKey{snapshotter: snapshotter, key: key}]; ok {
 		return sn, nil
 	}
 	return Snapshot{}, store.ErrNotExist
@@ -79,9 +88,9 @@ func (s *Store) List() []Snapshot {
 	return snapshots
 }
 
-// Delete deletes the snapshot with specified key.
-func (s *Store) Delete(key string) {
+// Delete deletes the snapshot with specified snapshotter and key.
+func (s *Store) Delete(snapshotter, key string) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
-	delete(s.snapshots, key)
+	delete(s.snapshots, snapshotKey{snapshotter: snapshotter, key: key})
 }
@@ -50,6 +50,14 @@ func TestSnapshotStore(t *testing.T) {
 			Timestamp: time.Now().UnixNano(),
 		},
 	}
+	otherSnapshotter := Snapshot{
+		Snapshotter: "other-snapshotter",
+		Key:         "key1",
+		Kind:        snapshot.KindCommitted,
+		Size:        30,
+		Inodes:      300,
+		Timestamp:   time.Now().UnixNano(),
+	}
 	assert := assertlib.New(t)
 
 	s := NewStore()
@@ -58,27 +66,33 @@ func TestSnapshotStore(t *testing.T) {
 	for _, sn := range snapshots {
 		s.Add(sn)
 	}
+	s.Add(otherSnapshotter)
 
 	t.Logf("should be able to get snapshot")
 	for id, sn := range snapshots {
-		got, err := s.Get(id)
+		got, err := s.Get("", id)
 		assert.NoError(err)
 		assert.Equal(sn, got)
 	}
 
+	t.Logf("should be able to get snapshot with the same key in other snapshotter")
+	got, err := s.Get(otherSnapshotter.Snapshotter, otherSnapshotter.Key)
+	assert.NoError(err)
+	assert.Equal(otherSnapshotter, got)
+
 	t.Logf("should be able to list snapshot")
 	sns := s.List()
-	assert.Len(sns, 3)
+	assert.Len(sns, 4)
 
 	testKey := "key2"
 
 	t.Logf("should be able to delete snapshot")
-	s.Delete(testKey)
+	s.Delete("", testKey)
 	sns = s.List()
-	assert.Len(sns, 2)
+	assert.Len(sns, 3)
 
 	t.Logf("get should return empty struct and ErrNotExist after deletion")
-	sn, err := s.Get(testKey)
+	sn, err := s.Get("", testKey)
 	assert.Equal(Snapshot{}, sn)
 	assert.Equal(store.ErrNotExist, err)
 }
@@ -0,0 +1,86 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"sync"
+
+	"github.com/containerd/cgroups"
+
+	"github.com/containerd/cri/pkg/store"
+)
+
+// ContainerStats contains the cpu/memory stats of a container.
+type ContainerStats struct {
+	// ID is the id of the container.
+	ID string
+	// Timestamp is the collection time (in nanoseconds) of the metrics.
+	Timestamp int64
+	// Metrics is the cgroup metrics of the container.
+	Metrics *cgroups.Metrics
+	// UsageNanoCores is the cpu usage in nanocores, computed between
+	// the last two samples. It is 0 if there is only one sample.
+	UsageNanoCores uint64
+}
+
+// Store stores stats of all containers.
+type Store struct {
+	lock  sync.RWMutex
+	stats map[string]ContainerStats
+}
+
+// NewStore creates a stats store.
+func NewStore() *Store {
+	return &Store{stats: make(map[string]ContainerStats)}
+}
+
+// Add container stats into the store. Existing stats of the same container
+// will be replaced.
+func (s *Store) Add(stats ContainerStats) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.stats[stats.ID] = stats
+}
+
+// Get returns the stats of the specified container. Returns store.ErrNotExist
+// if the stats don't exist.
+func (s *Store) Get(id string) (ContainerStats, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if stats, ok := s.stats[id]; ok {
+		return stats, nil
+	}
+	return ContainerStats{}, store.ErrNotExist
+}
+
+// List lists stats of all containers.
+func (s *Store) List() []ContainerStats {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	var stats []ContainerStats
+	for _, st := range s.stats {
+		stats = append(stats, st)
+	}
+	return stats
+}
+
+// Delete deletes the stats of the specified container.
+func (s *Store) Delete(id string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.stats, id)
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2018 The containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/containerd/cgroups"
+	assertlib "github.com/stretchr/testify/assert"
+
+	"github.com/containerd/cri/pkg/store"
+)
+
+func TestStatsStore(t *testing.T) {
+	stats := map[string]ContainerStats{
+		"id1": {
+			ID:        "id1",
+			Timestamp: time.Now().UnixNano(),
+			Metrics: &cgroups.Metrics{
+				CPU: &cgroups.CPUStat{Usage: &cgroups.CPUUsage{Total: 100}},
+			},
+			UsageNanoCores: 10,
+		},
+		"id2": {
+			ID:        "id2",
+			Timestamp: time.Now().UnixNano(),
+			Metrics: &cgroups.Metrics{
+				Memory: &cgroups.MemoryStat{Usage: &cgroups.MemoryEntry{Usage: 200}},
+			},
+		},
+		"id3": {
+			ID:        "id3",
+			Timestamp: time.Now().UnixNano(),
+		},
+	}
+	assert := assertlib.New(t)
+
+	s := NewStore()
+
+	t.Logf("should be able to add stats")
+	for _, st := range stats {
+		s.Add(st)
+	}
+
+	t.Logf("should be able to get stats")
+	for id, st := range stats {
+		got, err := s.Get(id)
+		assert.NoError(err)
+		assert.Equal(st, got)
+	}
+
+	t.Logf("should be able to list stats")
+	sts := s.List()
+	assert.Len(sts, 3)
+
+	testID := "id2"
+
+	t.Logf("should be able to replace stats")
+	updated := stats[testID]
+	updated.UsageNanoCores = 20
+	s.Add(updated)
+	got, err := s.Get(testID)
+	assert.NoError(err)
+	assert.Equal(updated, got)
+
+	t.Logf("should be able to delete stats")
+	s.Delete(testID)
+	sts = s.List()
+	assert.Len(sts, 2)
+
+	t.Logf("get should return empty struct and ErrNotExist after deletion")
+	st, err := s.Get(testID)
+	assert.Equal(ContainerStats{}, st)
+	assert.Equal(store.ErrNotExist, err)
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2018 The Containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userns
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Allocator allocates fixed size UID/GID ranges to pod sandboxes from a
+// subordinate ID range. Each sandbox owns at most one range.
+// Allocator is safe for concurrent access.
+type Allocator struct {
+	lock sync.Mutex
+	// start is the first host ID of the subordinate ID range.
+	start uint32
+	// podSize is the size of the range allocated to each sandbox.
+	podSize uint32
+	// blocks is the number of sandboxes using each allocatable range. A
+	// range reserved with a different config may use more than one
+	// allocatable range, and an allocatable range may be used by more than
+	// one reserved range.
+	blocks []int
+	// ranges maps sandbox id to its range.
+	ranges map[string]idRange
+}
+
+// idRange is the host id range [start, start+size).
+type idRange struct {
+	start uint32
+	size  uint32
+}
+
+// end returns the end of the range, which may exceed the maximum id.
+func (r idRange) end() uint64 {
+	return uint64(r.start) + uint64(r.size)
+}
+
+// overlaps returns true if the ranges share any id.
+func (r idRange) overlaps(o idRange) bool {
+	return uint64(r.start) < o.end() && uint64(o.start) < r.end()
+}
+
+// NewAllocator creates an Allocator allocating ranges of podSize from the
+// subordinate ID range [start, start+size).
+func NewAllocator(start, size, podSize uint32) (*Allocator, error) {
+	if podSize == 0 {
+		return nil, errors.New("pod range size must not be 0")
+	}
+	if size < podSize {
+		return nil, errors.Errorf("range size %d is smaller than pod range size %d", size, podSize)
+	}
+	if uint64(start)+uint64(size) > 1<<32 {
+		return nil, errors.Errorf("range [%d, %d) exceeds the maximum id", start, uint64(start)+uint64(size))
+	}
+	return &Allocator{
+		start:   start,
+		podSize: podSize,
+		blocks:  make([]int, size/podSize),
+		ranges:  make(map[string]idRange),
+	}, nil
+}
+
+// Size returns the size of the range allocated to each sandbox.
+func (a *Allocator) Size() uint32 {
+	return a.podSize
+}
+
+// Allocate allocates a range to the sandbox and returns the first host ID of
+// the range. The same range is returned if the sandbox already owns one.
+func (a *Allocator) Allocate(id string) (uint32, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if r, ok := a.ranges[id]; ok {
+		return r.start, nil
+	}
+	for i, users := range a.blocks {
+		if users != 0 {
+			continue
+		}
+		a.blocks[i]++
+		a.ranges[id] = idRange{start: a.hostID(i), size: a.podSize}
+		return a.ranges[id].start, nil
+	}
+	return 0, errors.Errorf("no free id range left for sandbox %q", id)
+}
+
+// Reserve reserves the range [hostID, hostID+size) for the sandbox. It is
+// used to recover allocations after restart. The range may be allocated with
+// a different config, all allocatable ranges overlapping with it are marked
+// as used, so that they are not allocated to other sandboxes. It returns
+// error if the range overlaps with the range of another sandbox.
+func (a *Allocator) Reserve(id string, hostID, size uint32) error {
+	if size == 0 {
+		return errors.New("range size must not be 0")
+	}
+	r := idRange{start: hostID, size: size}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if owned, ok := a.ranges[id]; ok {
+		if owned == r {
+			return nil
+		}
+		return errors.Errorf("sandbox %q already owns range [%d, %d)", id, owned.start, owned.end())
+	}
+	for other, o := range a.ranges {
+		if r.overlaps(o) {
+			return errors.Errorf("range [%d, %d) overlaps with range [%d, %d) of sandbox %q",
+				r.start, r.end(), o.start, o.end(), other)
+		}
+	}
+	a.updateBlocks(r, 1)
+	a.ranges[id] = r
+	return nil
+}
+
+// Release releases the range owned by the sandbox.
+func (a *Allocator) Release(id string) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	r, ok := a.ranges[id]
+	if !ok {
+		return
+	}
+	a.updateBlocks(r, -1)
+	delete(a.ranges, id)
+}
+
+// updateBlocks adds delta to the number of users of all allocatable ranges
+// overlapping with the range.
+func (a *Allocator) updateBlocks(r idRange, delta int) {
+	for i := range a.blocks {
+		if r.overlaps(idRange{start: a.hostID(i), size: a.podSize}) {
+			a.blocks[i] += delta
+		}
+	}
+}
+
+// hostID returns the first host ID of the range at index i.
+func (a *Allocator) hostID(i int) uint32 {
+	return a.start + uint32(i)*a.podSize
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2018 The Containerd Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userns
+
+import (
+	"testing"
+
+	assertlib "github.com/stretchr/testify/assert"
+	requirelib "github.com/stretchr/testify/require"
+)
+
+func TestNewAllocator(t *testing.T) {
+	for desc, test := range map[string]struct {
+		start     uint32
+		size      uint32
+		podSize   uint32
+		expectErr bool
+	}{
+		"valid range": {
+			start:   100000,
+			size:    65536 * 4,
+			podSize: 65536,
+		},
+		"zero pod size": {
+			start:     100000,
+			size:      65536,
+			expectErr: true,
+		},
+		"range smaller than pod size": {
+			start:     100000,
+			size:      1000,
+			podSize:   65536,
+			expectErr: true,
+		},
+		"range exceeds maximum id": {
+			start:     1<<32 - 1000,
+			size:      65536,
+			podSize:   65536,
+			expectErr: true,
+		},
+	} {
+		t.Logf("TestCase %q", desc)
+		_, err := NewAllocator(test.start, test.size, test.podSize)
+		assertlib.Equal(t, test.expectErr, err != nil)
+	}
+}
+
+func TestAllocator(t *testing.T) {
+	const (
+		start   = 100000
+		podSize = 65536
+	)
+	assert := assertlib.New(t)
+	require := requirelib.New(t)
+	a, err := NewAllocator(start, podSize*2+100, podSize)
+	require.NoError(err)
+
+	t.Logf("should be able to allocate a range")
+	id1, err := a.Allocate("sandbox-1")
+	require.NoError(err)
+	assert.EqualValues(start, id1)
+
+	t.Logf("should get the same range for the same sandbox")
+	id, err := a.Allocate("sandbox-1")
+	require.NoError(err)
+	assert.Equal(id1, id)
+
+	t.Logf("should be able to allocate another range")
+	id2, err := a.Allocate("sandbox-2")
+	require.NoError(err)
+	assert.EqualValues(start+podSize, id2)
+
+	t.Logf("should fail to allocate when ranges are exhausted")
+	_, err = a.Allocate("sandbox-3")
+	assert.Error(err)
+
+	t.Logf("should be able to allocate after release")
+	a.Release("sandbox-1")
+	id, err = a.Allocate("sandbox-3")
+	require.NoError(err)
+	assert.Equal(id1, id)
+
+	t.Logf("should be able to reserve a range owned by the same sandbox")
+	assert.NoError(a.Reserve("sandbox-3", id1, podSize))
+
+	t.Logf("should fail to reserve a range owned by another sandbox")
+	assert.Error(a.Reserve("sandbox-1", id2, podSize))
+
+	t.Logf("should fail to reserve a range overlapping with range of another sandbox")
+	assert.Error(a.Reserve("sandbox-1", id1+podSize/2, podSize))
+	assert.Error(a.Reserve("sandbox-1", id1-10, 20))
+
+	t.Logf("should fail to reserve an empty range")
+	a.Release("sandbox-2")
+	assert.Error(a.Reserve("sandbox-1", id2, 0))
+
+	t.Logf("should be able to reserve a released range")
+	assert.NoError(a.Reserve("sandbox-1", id2, podSize))
+	id, err = a.Allocate("sandbox-1")
+	require.NoError(err)
+	assert.Equal(id2, id)
+
+	t.Logf("should fail to reserve another range for the same sandbox")
+	assert.Error(a.Reserve("sandbox-1", start-podSize, podSize))
+}
+
+func TestAllocatorReserveWithDifferentConfig(t *testing.T) {
+	const (
+		start   = 100000
+		podSize = 65536
+	)
+	assert := assertlib.New(t)
+	require := requirelib.New(t)
+	a, err := NewAllocator(start, podSize*4, podSize)
+	require.NoError(err)
+
+	t.Logf("should mark all ranges overlapping with an unaligned range as used")
+	require.NoError(a.Reserve("sandbox-1", start+podSize/2, podSize))
+	t.Logf("should mark ranges overlapping with a range partially out of the allocatable range as used")
+	require.NoError(a.Reserve("sandbox-2", start+podSize*3, podSize*2))
+	t.Logf("should be able to reserve a range out of the allocatable range")
+	require.NoError(a.Reserve("sandbox-3", start-podSize, podSize))
+	id, err := a.Allocate("sandbox-3")
+	require.NoError(err)
+	assert.EqualValues(start-podSize, id)
+
+	t.Logf("should only allocate the range not overlapping with reserved ranges")
+	id, err = a.Allocate("sandbox-4")
+	require.NoError(err)
+	assert.EqualValues(start+podSize*2, id)
+	_, err = a.Allocate("sandbox-5")
+	assert.Error(err)
+
+	t.Logf("should free all overlapping ranges after release")
+	a.Release("sandbox-1")
+	id, err = a.Allocate("sandbox-5")
+	require.NoError(err)
+	assert.EqualValues(start, id)
+	id, err = a.Allocate("sandbox-6")
+	require.NoError(err)
+	assert.EqualValues(start+podSize, id)
+}
+
+func TestAllocatorReserveSmallerRanges(t *testing.T) {
+	const (
+		start   = 100000
+		podSize = 65536
+	)
+	assert := assertlib.New(t)
+	require := requirelib.New(t)
+	a, err := NewAllocator(start, podSize*2, podSize)
+	require.NoError(err)
+
+	t.Logf("should reserve disjoint ranges allocated with a smaller pod size in the same range")
+	require.NoError(a.Reserve("sandbox-1", start, podSize/2))
+	require.NoError(a.Reserve("sandbox-2", start+podSize/2, podSize/2))
+	assert.Error(a.Reserve("sandbox-3", start+podSize/4, podSize/2))
+
+	t.Logf("should not allocate the range until all reserved ranges in it are released")
+	id, err := a.Allocate("sandbox-4")
+	require.NoError(err)
+	assert.EqualValues(start+podSize, id)
+	a.Release("sandbox-1")
+	_, err = a.Allocate("sandbox-5")
+	assert.Error(err)
+	a.Release("sandbox-2")
+	id, err = a.Allocate("sandbox-5")
+	require.NoError(err)
+	assert.EqualValues(start, id)
+}